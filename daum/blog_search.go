@@ -22,6 +22,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/goccy/go-json"
 )
@@ -33,6 +34,20 @@ type BlogResult struct {
 	Thumbnail string `json:"thumbnail"`
 }
 
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// It is needed as the one of WebResult would be promoted and decode only its fields.
+func (br *BlogResult) UnmarshalJSON(data []byte) error {
+	if err := br.WebResult.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	aux := struct {
+		Blogname  *string `json:"blogname"`
+		Thumbnail *string `json:"thumbnail"`
+	}{&br.Blogname, &br.Thumbnail}
+	return json.Unmarshal(data, &aux)
+}
+
 // BlogSearchResult represents a blog search result.
 type BlogSearchResult struct {
 	Meta      common.PageableMeta `json:"meta"`
@@ -49,12 +64,15 @@ func (brs BlogSearchResults) SaveAs(filename string) error { return common.SaveA
 
 // BlogSearchIterator is a lazy blog search iterator.
 type BlogSearchIterator struct {
-	Query   string
-	Sort    string
-	Page    int
-	Size    int
-	AuthKey string
-	end     bool
+	Query     string
+	Sort      string
+	Page      int
+	Size      int
+	AuthKey   string
+	Clean     bool
+	SinceTime time.Time
+	UntilTime time.Time
+	end       bool
 }
 
 // BlogSearch allows to search blog posts by @query in the Daum Blog service.
@@ -119,6 +137,28 @@ func (it *BlogSearchIterator) Display(size int) *BlogSearchIterator {
 	return it
 }
 
+// CleanHTML makes the iterator strip the HTML tags and unescape the HTML entities
+// of the documents on decode.
+func (it *BlogSearchIterator) CleanHTML() *BlogSearchIterator {
+	it.Clean = true
+	return it
+}
+
+// Since limits the documents to the ones posted at or after @t.
+//
+// When sorted by recency, the iterator stops paging once the documents get older than @t.
+// The documents without a datetime are kept, since they cannot be told out of the window.
+func (it *BlogSearchIterator) Since(t time.Time) *BlogSearchIterator {
+	it.SinceTime = t
+	return it
+}
+
+// Until limits the documents to the ones posted at or before @t.
+func (it *BlogSearchIterator) Until(t time.Time) *BlogSearchIterator {
+	it.UntilTime = t
+	return it
+}
+
 // Next returns the blog search result and proceeds the iterator to the next page.
 func (it *BlogSearchIterator) Next() (res BlogSearchResult, err error) {
 	if it.end {
@@ -150,7 +190,30 @@ func (it *BlogSearchIterator) Next() (res BlogSearchResult, err error) {
 		return
 	}
 
-	it.end = res.Meta.IsEnd || 50 < it.Page
+	var (
+		docs = res.Documents[:0]
+		last time.Time
+	)
+	for _, doc := range res.Documents {
+		if !doc.Datetime.IsZero() {
+			last = doc.Datetime
+		}
+		if within(doc.Datetime, it.SinceTime, it.UntilTime) {
+			docs = append(docs, doc)
+		}
+	}
+	res.Documents = docs
+
+	// the documents sorted by recency get older page by page, so the last dated one tells if the rest are too old
+	expired := it.Sort == "recency" && !last.IsZero() && last.Before(it.SinceTime)
+
+	if it.Clean {
+		for idx := range res.Documents {
+			res.Documents[idx].clean()
+		}
+	}
+
+	it.end = res.Meta.IsEnd || expired || 50 < it.Page
 
 	it.Page++
 
@@ -158,7 +221,19 @@ func (it *BlogSearchIterator) Next() (res BlogSearchResult, err error) {
 }
 
 // CollectAll collects all the remaining blog search results.
+//
+// When sorted by recency with Since, the pages are fetched one by one until the documents get older than the window.
 func (it *BlogSearchIterator) CollectAll() (results BlogSearchResults) {
+	if it.Sort == "recency" && !it.SinceTime.IsZero() {
+		for {
+			result, err := it.Next()
+			if err != nil {
+				return
+			}
+			results = append(results, result)
+		}
+	}
+
 	result, err := it.Next()
 	if err == nil {
 		results = append(results, result)
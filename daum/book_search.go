@@ -23,6 +23,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/goccy/go-json"
 )
@@ -40,6 +41,26 @@ type BookResult struct {
 	Status      string   `json:"status"`
 }
 
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// It is needed as the one of WebResult would be promoted and decode only its fields.
+func (br *BookResult) UnmarshalJSON(data []byte) error {
+	if err := br.WebResult.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	aux := struct {
+		ISBN        *string   `json:"isbn"`
+		Authors     *[]string `json:"authors"`
+		Publisher   *string   `json:"publisher"`
+		Translators *[]string `json:"translators"`
+		Price       *int      `json:"price"`
+		SalePrice   *int      `json:"sale_price"`
+		Thumbnail   *string   `json:"thumbnail"`
+		Status      *string   `json:"status"`
+	}{&br.ISBN, &br.Authors, &br.Publisher, &br.Translators, &br.Price, &br.SalePrice, &br.Thumbnail, &br.Status}
+	return json.Unmarshal(data, &aux)
+}
+
 // BookSearchResult represents a Daum Book search result.
 type BookSearchResult struct {
 	Meta      common.PageableMeta `json:"meta"`
@@ -56,13 +77,16 @@ func (brs BookSearchResults) SaveAs(filename string) error { return common.SaveA
 
 // BookSearchIterator is a lazy book search iterator.
 type BookSearchIterator struct {
-	Query   string
-	AuthKey string
-	Sort    string
-	Page    int
-	Size    int
-	Target  string
-	end     bool
+	Query     string
+	AuthKey   string
+	Sort      string
+	Page      int
+	Size      int
+	Target    string
+	Clean     bool
+	SinceTime time.Time
+	UntilTime time.Time
+	end       bool
 }
 
 // BookSearch allows to search books by @query in the Daum Book service.
@@ -147,6 +171,28 @@ func (it *BookSearchIterator) Filter(target string) *BookSearchIterator {
 	return it
 }
 
+// CleanHTML makes the iterator strip the HTML tags and unescape the HTML entities
+// of the documents on decode.
+func (it *BookSearchIterator) CleanHTML() *BookSearchIterator {
+	it.Clean = true
+	return it
+}
+
+// Since limits the documents to the ones posted at or after @t.
+//
+// When sorted by latest, the iterator stops paging once the documents get older than @t.
+// The documents without a datetime are kept, since they cannot be told out of the window.
+func (it *BookSearchIterator) Since(t time.Time) *BookSearchIterator {
+	it.SinceTime = t
+	return it
+}
+
+// Until limits the documents to the ones posted at or before @t.
+func (it *BookSearchIterator) Until(t time.Time) *BookSearchIterator {
+	it.UntilTime = t
+	return it
+}
+
 // Next returns the book search result and proceeds the iterator to the next page.
 func (it *BookSearchIterator) Next() (res BookSearchResult, err error) {
 	if it.end {
@@ -176,14 +222,49 @@ func (it *BookSearchIterator) Next() (res BookSearchResult, err error) {
 	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
 		return
 	}
+
+	var (
+		docs = res.Documents[:0]
+		last time.Time
+	)
+	for _, doc := range res.Documents {
+		if !doc.Datetime.IsZero() {
+			last = doc.Datetime
+		}
+		if within(doc.Datetime, it.SinceTime, it.UntilTime) {
+			docs = append(docs, doc)
+		}
+	}
+	res.Documents = docs
+
+	// the documents sorted by latest get older page by page, so the last dated one tells if the rest are too old
+	expired := it.Sort == "latest" && !last.IsZero() && last.Before(it.SinceTime)
+
+	if it.Clean {
+		for idx := range res.Documents {
+			res.Documents[idx].clean()
+		}
+	}
 	it.Page++
-	it.end = res.Meta.IsEnd || 50 < it.Page
+	it.end = res.Meta.IsEnd || expired || 50 < it.Page
 
 	return
 }
 
 // CollectAll collects all the remaining book search results.
+//
+// When sorted by latest with Since, the pages are fetched one by one until the documents get older than the window.
 func (it *BookSearchIterator) CollectAll() (results BookSearchResults) {
+	if it.Sort == "latest" && !it.SinceTime.IsZero() {
+		for {
+			result, err := it.Next()
+			if err != nil {
+				return
+			}
+			results = append(results, result)
+		}
+	}
+
 	result, err := it.Next()
 	if err == nil {
 		results = append(results, result)
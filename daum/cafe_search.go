@@ -22,6 +22,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/goccy/go-json"
 )
@@ -33,6 +34,20 @@ type CafeResult struct {
 	Thumbnail string `json:"thumbnail"`
 }
 
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// It is needed as the one of WebResult would be promoted and decode only its fields.
+func (cr *CafeResult) UnmarshalJSON(data []byte) error {
+	if err := cr.WebResult.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	aux := struct {
+		CafeName  *string `json:"cafename"`
+		Thumbnail *string `json:"thumbnail"`
+	}{&cr.CafeName, &cr.Thumbnail}
+	return json.Unmarshal(data, &aux)
+}
+
 // CafeSearchResult represents a Daum Cafe search result.
 type CafeSearchResult struct {
 	Meta      common.PageableMeta `json:"meta"`
@@ -49,12 +64,15 @@ func (crs CafeSearchResults) SaveAs(filename string) error { return common.SaveA
 
 // CafeSearchIterator is a lazy cafe search iterator.
 type CafeSearchIterator struct {
-	Query   string
-	AuthKey string
-	Sort    string
-	Page    int
-	Size    int
-	end     bool
+	Query     string
+	AuthKey   string
+	Sort      string
+	Page      int
+	Size      int
+	Clean     bool
+	SinceTime time.Time
+	UntilTime time.Time
+	end       bool
 }
 
 // CafeSearch allows users to search posts by @query in the Daum Cafe service.
@@ -119,6 +137,28 @@ func (it *CafeSearchIterator) Display(size int) *CafeSearchIterator {
 	return it
 }
 
+// CleanHTML makes the iterator strip the HTML tags and unescape the HTML entities
+// of the documents on decode.
+func (it *CafeSearchIterator) CleanHTML() *CafeSearchIterator {
+	it.Clean = true
+	return it
+}
+
+// Since limits the documents to the ones posted at or after @t.
+//
+// When sorted by recency, the iterator stops paging once the documents get older than @t.
+// The documents without a datetime are kept, since they cannot be told out of the window.
+func (it *CafeSearchIterator) Since(t time.Time) *CafeSearchIterator {
+	it.SinceTime = t
+	return it
+}
+
+// Until limits the documents to the ones posted at or before @t.
+func (it *CafeSearchIterator) Until(t time.Time) *CafeSearchIterator {
+	it.UntilTime = t
+	return it
+}
+
 // Next returns the cafe search result and proceeds the iterator to the next page.
 func (it *CafeSearchIterator) Next() (res CafeSearchResult, err error) {
 	if it.end {
@@ -149,15 +189,50 @@ func (it *CafeSearchIterator) Next() (res CafeSearchResult, err error) {
 		return
 	}
 
+	var (
+		docs = res.Documents[:0]
+		last time.Time
+	)
+	for _, doc := range res.Documents {
+		if !doc.Datetime.IsZero() {
+			last = doc.Datetime
+		}
+		if within(doc.Datetime, it.SinceTime, it.UntilTime) {
+			docs = append(docs, doc)
+		}
+	}
+	res.Documents = docs
+
+	// the documents sorted by recency get older page by page, so the last dated one tells if the rest are too old
+	expired := it.Sort == "recency" && !last.IsZero() && last.Before(it.SinceTime)
+
+	if it.Clean {
+		for idx := range res.Documents {
+			res.Documents[idx].clean()
+		}
+	}
+
 	it.Page++
 
-	it.end = res.Meta.IsEnd || 50 < it.Page
+	it.end = res.Meta.IsEnd || expired || 50 < it.Page
 
 	return
 }
 
 // CollectAll collects all the remaining cafe search results.
+//
+// When sorted by recency with Since, the pages are fetched one by one until the documents get older than the window.
 func (it *CafeSearchIterator) CollectAll() (results CafeSearchResults) {
+	if it.Sort == "recency" && !it.SinceTime.IsZero() {
+		for {
+			result, err := it.Next()
+			if err != nil {
+				return
+			}
+			results = append(results, result)
+		}
+	}
+
 	result, err := it.Next()
 	if err == nil {
 		results = append(results, result)
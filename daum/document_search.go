@@ -22,16 +22,34 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/goccy/go-json"
 )
 
 // WebResult represents a document of a Daum search result.
 type WebResult struct {
-	Title    string `json:"title"`
-	Contents string `json:"contents"`
-	URL      string `json:"url"`
-	Datetime string `json:"datetime"`
+	Title    string    `json:"title"`
+	Contents string    `json:"contents"`
+	URL      string    `json:"url"`
+	Datetime time.Time `json:"datetime"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// An empty or null datetime is decoded to the zero time.
+func (wr *WebResult) UnmarshalJSON(data []byte) (err error) {
+	type shadow WebResult
+	aux := struct {
+		shadow
+		Datetime string `json:"datetime"`
+	}{shadow: shadow(*wr)}
+	if err = json.Unmarshal(data, &aux); err != nil {
+		return
+	}
+	*wr = WebResult(aux.shadow)
+	wr.Datetime, err = parseDatetime(aux.Datetime)
+	return
 }
 
 // DocumentSearchResult represents a Daum search result.
@@ -52,12 +70,15 @@ func (drs DocumentSearchResults) SaveAs(filename string) error {
 
 // DocumentSearchIterator is a lazy document search iterator.
 type DocumentSearchIterator struct {
-	Query   string
-	Sort    string
-	Page    int
-	Size    int
-	AuthKey string
-	end     bool
+	Query     string
+	Sort      string
+	Page      int
+	Size      int
+	AuthKey   string
+	Clean     bool
+	SinceTime time.Time
+	UntilTime time.Time
+	end       bool
 }
 
 // DocumentSearch allows to search web documents by @query in the Daum Search service.
@@ -122,6 +143,28 @@ func (it *DocumentSearchIterator) Display(size int) *DocumentSearchIterator {
 	return it
 }
 
+// CleanHTML makes the iterator strip the HTML tags and unescape the HTML entities
+// of the documents on decode.
+func (it *DocumentSearchIterator) CleanHTML() *DocumentSearchIterator {
+	it.Clean = true
+	return it
+}
+
+// Since limits the documents to the ones posted at or after @t.
+//
+// When sorted by recency, the iterator stops paging once the documents get older than @t.
+// The documents without a datetime are kept, since they cannot be told out of the window.
+func (it *DocumentSearchIterator) Since(t time.Time) *DocumentSearchIterator {
+	it.SinceTime = t
+	return it
+}
+
+// Until limits the documents to the ones posted at or before @t.
+func (it *DocumentSearchIterator) Until(t time.Time) *DocumentSearchIterator {
+	it.UntilTime = t
+	return it
+}
+
 // Next returns the document search result and proceeds the iterator to the next page.
 func (it *DocumentSearchIterator) Next() (res DocumentSearchResult, err error) {
 	if it.end {
@@ -153,7 +196,30 @@ func (it *DocumentSearchIterator) Next() (res DocumentSearchResult, err error) {
 		return
 	}
 
-	it.end = res.Meta.IsEnd || 50 < it.Page
+	var (
+		docs = res.Documents[:0]
+		last time.Time
+	)
+	for _, doc := range res.Documents {
+		if !doc.Datetime.IsZero() {
+			last = doc.Datetime
+		}
+		if within(doc.Datetime, it.SinceTime, it.UntilTime) {
+			docs = append(docs, doc)
+		}
+	}
+	res.Documents = docs
+
+	// the documents sorted by recency get older page by page, so the last dated one tells if the rest are too old
+	expired := it.Sort == "recency" && !last.IsZero() && last.Before(it.SinceTime)
+
+	if it.Clean {
+		for idx := range res.Documents {
+			res.Documents[idx].clean()
+		}
+	}
+
+	it.end = res.Meta.IsEnd || expired || 50 < it.Page
 
 	it.Page++
 
@@ -161,7 +227,19 @@ func (it *DocumentSearchIterator) Next() (res DocumentSearchResult, err error) {
 }
 
 // CollectAll collects all the remaining document search results.
+//
+// When sorted by recency with Since, the pages are fetched one by one until the documents get older than the window.
 func (it *DocumentSearchIterator) CollectAll() (results DocumentSearchResults) {
+	if it.Sort == "recency" && !it.SinceTime.IsZero() {
+		for {
+			result, err := it.Next()
+			if err != nil {
+				return
+			}
+			results = append(results, result)
+		}
+	}
+
 	result, err := it.Next()
 	if err == nil {
 		results = append(results, result)
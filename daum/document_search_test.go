@@ -15,9 +15,15 @@
 package daum_test
 
 import (
+	"fmt"
 	"internal/common"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/goccy/go-json"
 	"github.com/maengsanha/kakao-developers-client/daum"
 )
 
@@ -83,3 +89,110 @@ func TestDocumentSearchCollectAll(t *testing.T) {
 		t.Log(item)
 	}
 }
+
+func TestDocumentSearchSince(t *testing.T) {
+	query := "Alan Turing"
+	since := time.Now().AddDate(0, -1, 0)
+
+	it := daum.DocumentSearch(query).
+		AuthorizeWith(common.REST_API_KEY).
+		SortBy("recency").
+		Display(50).
+		Since(since)
+
+	for {
+		item, err := it.Next()
+		if err == daum.Done {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, doc := range item.Documents {
+			if doc.Datetime.Before(since) {
+				t.Errorf("unexpected document before %v: %+v", since, doc)
+			}
+		}
+	}
+}
+
+// stubTransport serves the requests by a handler instead of the network.
+type stubTransport http.HandlerFunc
+
+func (st stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	st(rec, req)
+	return rec.Result(), nil
+}
+
+// stubAPI makes the requests during @t be served by @handler.
+func stubAPI(t *testing.T, handler http.HandlerFunc) {
+	transport := http.DefaultTransport
+	http.DefaultTransport = stubTransport(handler)
+	t.Cleanup(func() { http.DefaultTransport = transport })
+}
+
+func TestWebResultEmptyDatetime(t *testing.T) {
+	var res daum.DocumentSearchResult
+	data := `{"documents": [{"title": "a", "datetime": ""}, {"title": "b", "datetime": "2022-03-01T10:00:00.000+09:00"}, {"datetime": null}]}`
+	if err := json.Unmarshal([]byte(data), &res); err != nil {
+		t.Fatal(err)
+	}
+	if !res.Documents[0].Datetime.IsZero() || res.Documents[1].Datetime.Year() != 2022 || !res.Documents[2].Datetime.IsZero() {
+		t.Errorf("unexpected datetimes: %+v", res.Documents)
+	}
+
+	var book daum.BookResult
+	data = `{"title": "c", "datetime": "", "isbn": "8996991341 9788996991342", "authors": ["Kim"], "price": 1000}`
+	if err := json.Unmarshal([]byte(data), &book); err != nil {
+		t.Fatal(err)
+	}
+	if book.Title != "c" || !book.Datetime.IsZero() || book.ISBN == "" || len(book.Authors) != 1 || book.Price != 1000 {
+		t.Errorf("unexpected book: %+v", book)
+	}
+}
+
+func TestBlogSearchSinceWithUndatedDocuments(t *testing.T) {
+	since := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	pages := map[string]string{
+		"1": `[{"title": "new", "datetime": "2022-03-05T10:00:00.000+09:00"}, {"title": "undated 1", "datetime": ""}]`,
+		"2": `[{"title": "newer", "datetime": "2022-03-02T10:00:00.000+09:00"}, {"title": "old", "datetime": "2022-02-01T10:00:00.000+09:00"}, {"title": "undated 2", "datetime": ""}]`,
+		"3": `[{"title": "older", "datetime": "2022-01-01T10:00:00.000+09:00"}]`,
+	}
+	var requests int32
+	stubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprintf(w, `{"meta": {"total_count": 100, "pageable_count": 100, "is_end": false}, "documents": %s}`, pages[r.URL.Query().Get("page")])
+	})
+
+	results := daum.BlogSearch("kakao").SortBy("recency").Since(since).CollectAll()
+
+	var titles []string
+	for _, result := range results {
+		for _, doc := range result.Documents {
+			titles = append(titles, doc.Title)
+		}
+	}
+	if fmt.Sprint(titles) != "[new undated 1 newer undated 2]" || requests != 2 {
+		t.Errorf("unexpected documents %v after %d requests", titles, requests)
+	}
+}
+
+func TestDocumentSearchCollectAllSince(t *testing.T) {
+	since := time.Now().AddDate(0, 0, -7)
+
+	results := daum.DocumentSearch("Alan Turing").
+		AuthorizeWith(common.REST_API_KEY).
+		SortBy("recency").
+		Display(50).
+		Since(since).
+		CollectAll()
+
+	for _, item := range results {
+		for _, doc := range item.Documents {
+			if doc.Datetime.Before(since) {
+				t.Errorf("unexpected document before %v: %+v", since, doc)
+			}
+		}
+	}
+}
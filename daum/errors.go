@@ -14,6 +14,19 @@
 
 package daum
 
-import "internal/common"
+import (
+	"errors"
+	"internal/common"
+)
 
-var Done = common.ErrEndPage
+var (
+	Done                   = common.ErrEndPage
+	ErrUnsupportedSource   = errors.New("source must be either blog or cafe")
+	ErrNonPositiveInterval = errors.New("interval must be positive")
+	ErrInvalidISBN         = errors.New("invalid ISBN")
+	ErrWorkersOutOfBound   = errors.New("workers must be between 1 and 32")
+	ErrNegativeDuration    = errors.New("duration must not be negative")
+	ErrEmptyQuery          = errors.New("query must have a term to search")
+	ErrQueryTooLong        = errors.New("query is too long")
+	ErrInvalidSite         = errors.New("invalid site domain")
+)
@@ -0,0 +1,123 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// Highlight represents a highlighted term of a search result.
+//
+// Start and End are the rune offsets of Term in the plain text.
+type Highlight struct {
+	Term  string `json:"term"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// PlainText strips the HTML tags of @s and unescapes its HTML entities.
+func PlainText(s string) string {
+	text, _ := parseHighlights(s)
+	return text
+}
+
+// Highlights returns the terms of @s highlighted with <b> tags.
+func Highlights(s string) []Highlight {
+	_, highlights := parseHighlights(s)
+	return highlights
+}
+
+func parseHighlights(s string) (string, []Highlight) {
+	var (
+		text       strings.Builder
+		highlights []Highlight
+		offset     int
+		open       = -1
+		openByte   int
+		runes      int
+	)
+
+	for _, loc := range append(tagPattern.FindAllStringIndex(s, -1), []int{len(s), len(s)}) {
+		plain := html.UnescapeString(s[offset:loc[0]])
+		text.WriteString(plain)
+		runes += utf8.RuneCountInString(plain)
+		offset = loc[1]
+
+		switch strings.ToLower(strings.Join(strings.Fields(s[loc[0]:loc[1]]), "")) {
+		case "<b>":
+			open, openByte = runes, text.Len()
+		case "</b>":
+			if open < 0 {
+				continue
+			}
+			if open < runes {
+				highlights = append(highlights, Highlight{
+					Term:  text.String()[openByte:],
+					Start: open,
+					End:   runes,
+				})
+			}
+			open = -1
+		}
+	}
+
+	return text.String(), highlights
+}
+
+// PlainTitle returns the title of wr in plain text.
+func (wr WebResult) PlainTitle() string { return PlainText(wr.Title) }
+
+// PlainContents returns the contents of wr in plain text.
+func (wr WebResult) PlainContents() string { return PlainText(wr.Contents) }
+
+// TitleHighlights returns the highlighted terms of the title of wr.
+func (wr WebResult) TitleHighlights() []Highlight { return Highlights(wr.Title) }
+
+// ContentsHighlights returns the highlighted terms of the contents of wr.
+func (wr WebResult) ContentsHighlights() []Highlight { return Highlights(wr.Contents) }
+
+func (wr *WebResult) clean() {
+	wr.Title = PlainText(wr.Title)
+	wr.Contents = PlainText(wr.Contents)
+}
+
+// PlainBlogname returns the blog name of br in plain text.
+func (br BlogResult) PlainBlogname() string { return PlainText(br.Blogname) }
+
+func (br *BlogResult) clean() {
+	br.WebResult.clean()
+	br.Blogname = PlainText(br.Blogname)
+}
+
+// PlainCafeName returns the cafe name of cr in plain text.
+func (cr CafeResult) PlainCafeName() string { return PlainText(cr.CafeName) }
+
+func (cr *CafeResult) clean() {
+	cr.WebResult.clean()
+	cr.CafeName = PlainText(cr.CafeName)
+}
+
+// PlainPublisher returns the publisher of br in plain text.
+func (br BookResult) PlainPublisher() string { return PlainText(br.Publisher) }
+
+func (br *BookResult) clean() {
+	br.WebResult.clean()
+	br.Publisher = PlainText(br.Publisher)
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum_test
+
+import (
+	"internal/common"
+	"strings"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/daum"
+)
+
+func TestHighlights(t *testing.T) {
+	wr := daum.WebResult{
+		Title:    "영화 <b>이미테이션</b> <b>게임</b> &amp; 앨런 튜링",
+		Contents: "&lt;<b>Imitation Game</b>&gt; is a 2014 film",
+	}
+
+	if title := wr.PlainTitle(); title != "영화 이미테이션 게임 & 앨런 튜링" {
+		t.Errorf("unexpected plain title: %s", title)
+	}
+	if contents := wr.PlainContents(); contents != "<Imitation Game> is a 2014 film" {
+		t.Errorf("unexpected plain contents: %s", contents)
+	}
+
+	highlights := wr.TitleHighlights()
+	if len(highlights) != 2 {
+		t.Fatalf("expected 2 highlights, got %v", highlights)
+	}
+	if h := highlights[0]; h.Term != "이미테이션" || h.Start != 3 || h.End != 8 {
+		t.Errorf("unexpected highlight: %+v", h)
+	}
+	if h := highlights[1]; h.Term != "게임" || h.Start != 9 || h.End != 11 {
+		t.Errorf("unexpected highlight: %+v", h)
+	}
+
+	if h := wr.ContentsHighlights(); len(h) != 1 || h[0].Term != "Imitation Game" || h[0].Start != 1 {
+		t.Errorf("unexpected highlights: %+v", h)
+	}
+
+	br := daum.BlogResult{WebResult: wr, Blogname: "Tom &amp; Jerry"}
+	if br.PlainTitle() != wr.PlainTitle() || br.PlainBlogname() != "Tom & Jerry" {
+		t.Errorf("unexpected blog result: %s, %s", br.PlainTitle(), br.PlainBlogname())
+	}
+}
+
+func TestBlogSearchCleanHTML(t *testing.T) {
+	query := "Imitation Game"
+
+	it := daum.BlogSearch(query).
+		AuthorizeWith(common.REST_API_KEY).
+		CleanHTML().
+		Display(10)
+
+	res, err := it.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, doc := range res.Documents {
+		if strings.Contains(doc.Title, "<b>") || strings.Contains(doc.Contents, "<b>") {
+			t.Errorf("unexpected HTML tags: %+v", doc)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"internal/common"
+	"io"
+	"io/ioutil"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxImageSize is the maximum number of bytes downloaded per image.
+const maxImageSize = 32 << 20
+
+// DownloadedImage represents an image saved by an image downloader.
+type DownloadedImage struct {
+	File            string `json:"file"`
+	URL             string `json:"url"`
+	DocURL          string `json:"doc_url"`
+	DisplaySitename string `json:"display_sitename"`
+	ContentType     string `json:"content_type"`
+	Width           int    `json:"width"`
+	Height          int    `json:"height"`
+	SHA256          string `json:"sha256"`
+}
+
+// SkippedImage represents an image an image downloader did not save, with the reason why.
+type SkippedImage struct {
+	URL    string `json:"url"`
+	DocURL string `json:"doc_url"`
+	Reason string `json:"reason"`
+}
+
+// ImageManifest represents the images saved by an image downloader.
+type ImageManifest struct {
+	Images  []DownloadedImage `json:"images"`
+	Skipped []SkippedImage    `json:"skipped"`
+}
+
+// String implements fmt.Stringer.
+func (im ImageManifest) String() string { return common.String(im) }
+
+// SaveAs saves im to @filename.
+func (im ImageManifest) SaveAs(filename string) error { return common.SaveAsJSON(im, filename) }
+
+// ImageDownloadInitializer is a lazy image downloader.
+type ImageDownloadInitializer struct {
+	Images    []ImageResult
+	Dir       string
+	Thumbnail bool
+	Workers   int
+}
+
+// DownloadImages downloads the images of @irs.
+//
+// The images are saved in the images directory by default.
+func DownloadImages(irs ImageSearchResults) *ImageDownloadInitializer {
+	var images []ImageResult
+	for _, ir := range irs {
+		images = append(images, ir.Documents...)
+	}
+	return &ImageDownloadInitializer{
+		Images:    images,
+		Dir:       "images",
+		Thumbnail: false,
+		Workers:   4,
+	}
+}
+
+// To sets the directory the images are saved in to @dir.
+func (di *ImageDownloadInitializer) To(dir string) *ImageDownloadInitializer {
+	di.Dir = dir
+	return di
+}
+
+// Thumbnails makes the downloader fetch the thumbnails instead of the original images.
+//
+// Since the thumbnails are resized, their dimensions are not verified.
+func (di *ImageDownloadInitializer) Thumbnails() *ImageDownloadInitializer {
+	di.Thumbnail = true
+	return di
+}
+
+// Concurrency sets the maximum number of concurrent downloads to @workers (a value between 1 and 32).
+func (di *ImageDownloadInitializer) Concurrency(workers int) *ImageDownloadInitializer {
+	if 1 <= workers && workers <= 32 {
+		di.Workers = workers
+	} else {
+		panic(ErrWorkersOutOfBound)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return di
+}
+
+// Collect downloads the images and writes their manifest to manifest.json in the directory.
+//
+// The images which are not images, do not match their dimensions,
+// or have the same content as another one are skipped and reported in the manifest.
+func (di *ImageDownloadInitializer) Collect() (manifest ImageManifest, err error) {
+	if err = os.MkdirAll(di.Dir, 0755); err != nil {
+		return
+	}
+
+	var (
+		images  = make([]*DownloadedImage, len(di.Images))
+		reasons = make([]string, len(di.Images))
+		jobs    = make(chan int)
+		wg      sync.WaitGroup
+		client  = &http.Client{Timeout: 30 * time.Second}
+	)
+
+	for worker := 0; worker < di.Workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				images[idx], reasons[idx] = di.download(client, di.Images[idx])
+			}
+		}()
+	}
+	for idx := range di.Images {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+
+	// the images are deduplicated in their order, so that the first of the same ones is kept in the manifest
+	hashes := map[string]string{}
+	for idx, doc := range di.Images {
+		img, reason := images[idx], reasons[idx]
+		if img != nil {
+			if file, dup := hashes[img.SHA256]; dup {
+				img, reason = nil, "duplicate of "+file
+			} else {
+				hashes[img.SHA256] = img.File
+			}
+		}
+
+		if img != nil {
+			manifest.Images = append(manifest.Images, *img)
+		} else {
+			manifest.Skipped = append(manifest.Skipped, SkippedImage{URL: di.url(doc), DocURL: doc.DocURL, Reason: reason})
+		}
+	}
+
+	err = manifest.SaveAs(filepath.Join(di.Dir, "manifest.json"))
+	return
+}
+
+// url returns the URL of @doc to download.
+func (di *ImageDownloadInitializer) url(doc ImageResult) string {
+	if di.Thumbnail {
+		return doc.ThumbnailURL
+	}
+	return doc.ImageURL
+}
+
+// download fetches @doc, verifies it and writes it to the directory, naming it after its content hash.
+// If the image is rejected, download returns the reason why.
+func (di *ImageDownloadInitializer) download(client *http.Client, doc ImageResult) (*DownloadedImage, string) {
+	link := di.url(doc)
+	if link == "" {
+		return nil, "no URL"
+	}
+
+	resp, err := client.Get(link)
+	if err != nil {
+		return nil, err.Error()
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.Status
+	}
+
+	contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, fmt.Sprintf("unexpected content type %q", contentType)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxImageSize+1))
+	if err != nil {
+		return nil, err.Error()
+	}
+	if len(body) > maxImageSize {
+		return nil, "too large"
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(body))
+	if err != nil {
+		return nil, "undecodable image"
+	}
+	if !di.Thumbnail && 0 < doc.Width && 0 < doc.Height && (config.Width != doc.Width || config.Height != doc.Height) {
+		return nil, fmt.Sprintf("expected %dx%d, got %dx%d", doc.Width, doc.Height, config.Width, config.Height)
+	}
+
+	sum := sha256.Sum256(body)
+	hash := hex.EncodeToString(sum[:])
+	file := hash[:16] + "." + format
+	if format == "jpeg" {
+		file = hash[:16] + ".jpg"
+	}
+	if err := writeFile(filepath.Join(di.Dir, file), body); err != nil {
+		return nil, err.Error()
+	}
+	return &DownloadedImage{
+		File:            file,
+		URL:             link,
+		DocURL:          doc.DocURL,
+		DisplaySitename: doc.DisplaySitename,
+		ContentType:     contentType,
+		Width:           config.Width,
+		Height:          config.Height,
+		SHA256:          hash,
+	}, ""
+}
+
+// writeFile writes @body to @filename through a temporary file,
+// so that the same images written at once leave a whole file.
+func writeFile(filename string, body []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), ".download-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
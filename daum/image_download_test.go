@@ -0,0 +1,77 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/maengsanha/kakao-developers-client/daum"
+)
+
+func TestDownloadImages(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 3))); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a.png", "/b.png":
+			// the first image arrives last
+			if r.URL.Query().Get("slow") != "" {
+				time.Sleep(100 * time.Millisecond)
+			}
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(buf.Bytes())
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html></html>"))
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	manifest, err := daum.DownloadImages(daum.ImageSearchResults{{Documents: []daum.ImageResult{
+		{ImageURL: server.URL + "/a.png?slow=1", Width: 4, Height: 3, DocURL: "https://example.com/a", DisplaySitename: "A"},
+		{ImageURL: server.URL + "/b.png", Width: 4, Height: 3, DocURL: "https://example.com/b"},
+		{ImageURL: server.URL + "/a.png", Width: 8, Height: 6},
+		{ImageURL: server.URL + "/page"},
+	}}}).To(dir).Concurrency(4).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(manifest.Images) != 1 || manifest.Images[0].DisplaySitename != "A" || len(manifest.Skipped) != 3 {
+		t.Fatalf("unexpected manifest: %v", manifest)
+	}
+	if skipped := manifest.Skipped[0]; skipped.DocURL != "https://example.com/b" || skipped.Reason != "duplicate of "+manifest.Images[0].File {
+		t.Errorf("expected the later image to be the duplicate, got %+v", skipped)
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 || files[0].Name() != manifest.Images[0].File || files[1].Name() != "manifest.json" {
+		t.Errorf("unexpected files: %v", files)
+	}
+}
@@ -39,6 +39,23 @@ type ImageResult struct {
 	Datetime        time.Time `json:"datetime"`
 }
 
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// An empty or null datetime is decoded to the zero time.
+func (ir *ImageResult) UnmarshalJSON(data []byte) (err error) {
+	type shadow ImageResult
+	aux := struct {
+		shadow
+		Datetime string `json:"datetime"`
+	}{shadow: shadow(*ir)}
+	if err = json.Unmarshal(data, &aux); err != nil {
+		return
+	}
+	*ir = ImageResult(aux.shadow)
+	ir.Datetime, err = parseDatetime(aux.Datetime)
+	return
+}
+
 // ImageSearchResult represents an image search result.
 type ImageSearchResult struct {
 	Meta      common.PageableMeta `json:"meta"`
@@ -55,12 +72,14 @@ func (irs ImageSearchResults) SaveAs(filename string) error { return common.Save
 
 // ImageSearchIterator is a lazy image search iterator.
 type ImageSearchIterator struct {
-	Query   string
-	Sort    string
-	Page    int
-	Size    int
-	AuthKey string
-	end     bool
+	Query     string
+	Sort      string
+	Page      int
+	Size      int
+	AuthKey   string
+	SinceTime time.Time
+	UntilTime time.Time
+	end       bool
 }
 
 // ImageSearch allows users to search images by @query in the Daum Search service.
@@ -125,6 +144,21 @@ func (it *ImageSearchIterator) Display(size int) *ImageSearchIterator {
 	return it
 }
 
+// Since limits the documents to the ones posted at or after @t.
+//
+// When sorted by recency, the iterator stops paging once the documents get older than @t.
+// The documents without a datetime are kept, since they cannot be told out of the window.
+func (it *ImageSearchIterator) Since(t time.Time) *ImageSearchIterator {
+	it.SinceTime = t
+	return it
+}
+
+// Until limits the documents to the ones posted at or before @t.
+func (it *ImageSearchIterator) Until(t time.Time) *ImageSearchIterator {
+	it.UntilTime = t
+	return it
+}
+
 // Next returns the image search result and proceeds the iterator to the next page.
 func (it *ImageSearchIterator) Next() (res ImageSearchResult, err error) {
 	if it.end {
@@ -156,7 +190,24 @@ func (it *ImageSearchIterator) Next() (res ImageSearchResult, err error) {
 		return
 	}
 
-	it.end = res.Meta.IsEnd || 50 < it.Page
+	var (
+		docs = res.Documents[:0]
+		last time.Time
+	)
+	for _, doc := range res.Documents {
+		if !doc.Datetime.IsZero() {
+			last = doc.Datetime
+		}
+		if within(doc.Datetime, it.SinceTime, it.UntilTime) {
+			docs = append(docs, doc)
+		}
+	}
+	res.Documents = docs
+
+	// the documents sorted by recency get older page by page, so the last dated one tells if the rest are too old
+	expired := it.Sort == "recency" && !last.IsZero() && last.Before(it.SinceTime)
+
+	it.end = res.Meta.IsEnd || expired || 50 < it.Page
 
 	it.Page++
 
@@ -164,7 +215,18 @@ func (it *ImageSearchIterator) Next() (res ImageSearchResult, err error) {
 }
 
 // CollectAll collects all the remaining image search results.
+//
+// When sorted by recency with Since, the pages are fetched one by one until the documents get older than the window.
 func (it *ImageSearchIterator) CollectAll() (results ImageSearchResults) {
+	if it.Sort == "recency" && !it.SinceTime.IsZero() {
+		for {
+			result, err := it.Next()
+			if err != nil {
+				return
+			}
+			results = append(results, result)
+		}
+	}
 
 	result, err := it.Next()
 	if err == nil {
@@ -0,0 +1,226 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NormalizeISBN strips the hyphens and spaces of @isbn and upper-cases its check digit.
+func NormalizeISBN(isbn string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(isbn)))
+}
+
+// ValidISBN10 reports whether @isbn is a normalized ISBN-10 with a valid check digit.
+func ValidISBN10(isbn string) bool {
+	if len(isbn) != 10 {
+		return false
+	}
+	sum := 0
+	for idx, ch := range isbn {
+		var digit int
+		switch {
+		case '0' <= ch && ch <= '9':
+			digit = int(ch - '0')
+		case ch == 'X' && idx == 9:
+			digit = 10
+		default:
+			return false
+		}
+		sum += (10 - idx) * digit
+	}
+	return sum%11 == 0
+}
+
+// ValidISBN13 reports whether @isbn is a normalized ISBN-13 with a valid check digit.
+func ValidISBN13(isbn string) bool {
+	if len(isbn) != 13 || !isDigits(isbn) {
+		return false
+	}
+	return checkDigit13(isbn[:12]) == isbn[12]
+}
+
+// ISBN10To13 converts @isbn from ISBN-10 to ISBN-13.
+func ISBN10To13(isbn string) (string, error) {
+	if isbn = NormalizeISBN(isbn); !ValidISBN10(isbn) {
+		return "", ErrInvalidISBN
+	}
+	body := "978" + isbn[:9]
+	return body + string(checkDigit13(body)), nil
+}
+
+// ISBN13To10 converts @isbn from ISBN-13 to ISBN-10.
+//
+// Only an ISBN-13 with the 978 prefix has its ISBN-10.
+func ISBN13To10(isbn string) (string, error) {
+	if isbn = NormalizeISBN(isbn); !ValidISBN13(isbn) || !strings.HasPrefix(isbn, "978") {
+		return "", ErrInvalidISBN
+	}
+	body := isbn[3:12]
+	sum := 0
+	for idx, ch := range body {
+		sum += (10 - idx) * int(ch-'0')
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return body + "X", nil
+	}
+	return body + strconv.Itoa(check), nil
+}
+
+func checkDigit13(body string) byte {
+	sum := 0
+	for idx, ch := range body {
+		if idx%2 == 0 {
+			sum += int(ch - '0')
+		} else {
+			sum += 3 * int(ch-'0')
+		}
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func isDigits(s string) bool {
+	for _, ch := range s {
+		if ch < '0' || '9' < ch {
+			return false
+		}
+	}
+	return true
+}
+
+// ISBN10 returns the valid ISBN-10 of br, or an empty string if it has none.
+func (br BookResult) ISBN10() string {
+	isbn10, _ := br.isbns()
+	return isbn10
+}
+
+// ISBN13 returns the valid ISBN-13 of br, or an empty string if it has none.
+func (br BookResult) ISBN13() string {
+	_, isbn13 := br.isbns()
+	return isbn13
+}
+
+// isbns parses the space-separated ISBN of br, and derives a missing one from the other.
+func (br BookResult) isbns() (isbn10, isbn13 string) {
+	for _, token := range strings.Fields(br.ISBN) {
+		switch token = NormalizeISBN(token); {
+		case ValidISBN10(token):
+			isbn10 = token
+		case ValidISBN13(token):
+			isbn13 = token
+		}
+	}
+	if isbn10 == "" && isbn13 != "" {
+		isbn10, _ = ISBN13To10(isbn13)
+	}
+	if isbn13 == "" && isbn10 != "" {
+		isbn13, _ = ISBN10To13(isbn10)
+	}
+	return
+}
+
+// Discount returns the discount rate of br in percent.
+//
+// A book which is not on sale has no discount.
+func (br BookResult) Discount() float64 {
+	if br.Price <= 0 || br.SalePrice < 0 {
+		return 0
+	}
+	return float64(br.Price-br.SalePrice) / float64(br.Price) * 100
+}
+
+// Available reports whether br is on normal sale.
+func (br BookResult) Available() bool { return br.Status == "정상판매" }
+
+// LookupISBN searches the book with @isbn (either ISBN-10 or ISBN-13).
+func LookupISBN(isbn string) *BookSearchIterator {
+	return BookSearch(NormalizeISBN(isbn)).Filter("isbn")
+}
+
+// BookStats represents the price statistics of a group of books.
+//
+// The sale prices and discounts are averaged over the books on sale only.
+type BookStats struct {
+	Count            int     `json:"count"`
+	MinPrice         int     `json:"min_price"`
+	MaxPrice         int     `json:"max_price"`
+	AveragePrice     float64 `json:"average_price"`
+	AverageSalePrice float64 `json:"average_sale_price"`
+	AverageDiscount  float64 `json:"average_discount"`
+	onSale           int
+}
+
+func (bs *BookStats) add(br BookResult) {
+	if bs.Count == 0 || br.Price < bs.MinPrice {
+		bs.MinPrice = br.Price
+	}
+	if bs.Count == 0 || bs.MaxPrice < br.Price {
+		bs.MaxPrice = br.Price
+	}
+	bs.AveragePrice = (bs.AveragePrice*float64(bs.Count) + float64(br.Price)) / float64(bs.Count+1)
+	bs.Count++
+
+	if 0 <= br.SalePrice {
+		bs.AverageSalePrice = (bs.AverageSalePrice*float64(bs.onSale) + float64(br.SalePrice)) / float64(bs.onSale+1)
+		bs.AverageDiscount = (bs.AverageDiscount*float64(bs.onSale) + br.Discount()) / float64(bs.onSale+1)
+		bs.onSale++
+	}
+}
+
+// books returns the documents of brs, without the ones appearing more than once.
+func (brs BookSearchResults) books() (books []BookResult) {
+	seen := map[string]bool{}
+	for _, br := range brs {
+		for _, book := range br.Documents {
+			key := book.ISBN13()
+			if key == "" {
+				key = book.Title + "\x00" + book.Publisher
+			}
+			if !seen[key] {
+				seen[key] = true
+				books = append(books, book)
+			}
+		}
+	}
+	return
+}
+
+// ByPublisher returns the price statistics of brs per publisher.
+func (brs BookSearchResults) ByPublisher() map[string]BookStats {
+	stats := map[string]BookStats{}
+	for _, book := range brs.books() {
+		bs := stats[book.Publisher]
+		bs.add(book)
+		stats[book.Publisher] = bs
+	}
+	return stats
+}
+
+// ByAuthor returns the price statistics of brs per author.
+//
+// A book with several authors counts for each of them.
+func (brs BookSearchResults) ByAuthor() map[string]BookStats {
+	stats := map[string]BookStats{}
+	for _, book := range brs.books() {
+		for _, author := range book.Authors {
+			bs := stats[author]
+			bs.add(book)
+			stats[author] = bs
+		}
+	}
+	return stats
+}
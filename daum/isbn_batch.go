@@ -0,0 +1,220 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum
+
+import (
+	"internal/common"
+	"log"
+	"strings"
+	"sync"
+)
+
+// BookCache is a pluggable cache of the books found by their ISBN-13.
+type BookCache interface {
+	Get(isbn13 string) ([]BookResult, bool)
+	Set(isbn13 string, books []BookResult)
+}
+
+// MemoryBookCache is a BookCache kept in memory.
+type MemoryBookCache struct {
+	mu    sync.RWMutex
+	books map[string][]BookResult
+}
+
+// NewMemoryBookCache returns an empty MemoryBookCache.
+func NewMemoryBookCache() *MemoryBookCache {
+	return &MemoryBookCache{books: map[string][]BookResult{}}
+}
+
+// Get implements BookCache.
+func (mc *MemoryBookCache) Get(isbn13 string) (books []BookResult, ok bool) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	books, ok = mc.books[isbn13]
+	return
+}
+
+// Set implements BookCache.
+func (mc *MemoryBookCache) Set(isbn13 string, books []BookResult) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.books[isbn13] = books
+}
+
+// ISBNLookupResult represents a bulk ISBN lookup result, keyed by the input ISBNs.
+//
+// Ambiguous holds the ISBNs matching more than one book,
+// and Failed the ones whose lookup failed.
+type ISBNLookupResult struct {
+	Matches   map[string]BookResult   `json:"matches"`
+	Ambiguous map[string][]BookResult `json:"ambiguous"`
+	NotFound  []string                `json:"not_found"`
+	Invalid   []string                `json:"invalid"`
+	Failed    []string                `json:"failed"`
+}
+
+// String implements fmt.Stringer.
+func (lr ISBNLookupResult) String() string { return common.String(lr) }
+
+// SaveAs saves lr to @filename.
+func (lr ISBNLookupResult) SaveAs(filename string) error { return common.SaveAsJSON(lr, filename) }
+
+// ISBNLookupInitializer is a lazy bulk ISBN lookup.
+type ISBNLookupInitializer struct {
+	ISBNs   []string
+	AuthKey string
+	Workers int
+	Cache   BookCache
+}
+
+// LookupISBNs searches the books with @isbns (either ISBN-10 or ISBN-13) in bulk.
+func LookupISBNs(isbns ...string) *ISBNLookupInitializer {
+	return &ISBNLookupInitializer{
+		ISBNs:   isbns,
+		AuthKey: common.KeyPrefix,
+		Workers: 4,
+		Cache:   NewMemoryBookCache(),
+	}
+}
+
+// AuthorizeWith sets the authorization key to @key.
+func (li *ISBNLookupInitializer) AuthorizeWith(key string) *ISBNLookupInitializer {
+	li.AuthKey = common.FormatKey(key)
+	return li
+}
+
+// Concurrency sets the maximum number of concurrent requests to @workers (a value between 1 and 32).
+func (li *ISBNLookupInitializer) Concurrency(workers int) *ISBNLookupInitializer {
+	if 1 <= workers && workers <= 32 {
+		li.Workers = workers
+	} else {
+		panic(ErrWorkersOutOfBound)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return li
+}
+
+// CacheWith sets the cache of the found books to @cache.
+func (li *ISBNLookupInitializer) CacheWith(cache BookCache) *ISBNLookupInitializer {
+	li.Cache = cache
+	return li
+}
+
+// Collect looks up all the ISBNs and matches the found books back to them by their ISBN-13.
+//
+// If some lookups fail, Collect returns the first error along with the result of the others.
+func (li *ISBNLookupInitializer) Collect() (res ISBNLookupResult, err error) {
+	res.Matches = map[string]BookResult{}
+	res.Ambiguous = map[string][]BookResult{}
+
+	// normalize the input ISBNs to ISBN-13 to look up each book once
+	var (
+		keys    = map[string]string{}
+		seen    = map[string]bool{}
+		queued  = map[string]bool{}
+		inputs  []string
+		pending []string
+	)
+	for _, isbn := range li.ISBNs {
+		isbn = strings.TrimSpace(isbn)
+		if seen[isbn] {
+			continue
+		}
+		seen[isbn] = true
+
+		key := toISBN13(isbn)
+		if key == "" {
+			res.Invalid = append(res.Invalid, isbn)
+			continue
+		}
+		keys[isbn] = key
+		inputs = append(inputs, isbn)
+		if _, ok := li.Cache.Get(key); !ok && !queued[key] {
+			queued[key] = true
+			pending = append(pending, key)
+		}
+	}
+
+	var (
+		errors = make([]error, len(pending))
+		jobs   = make(chan int)
+		wg     sync.WaitGroup
+	)
+
+	for worker := 0; worker < li.Workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				key := pending[idx]
+				it := LookupISBN(key).Display(50)
+				it.AuthKey = li.AuthKey
+
+				found, err := it.Next()
+				if err != nil {
+					errors[idx] = err
+					continue
+				}
+
+				var books []BookResult
+				for _, book := range found.Documents {
+					if book.ISBN13() == key {
+						books = append(books, book)
+					}
+				}
+				li.Cache.Set(key, books)
+			}
+		}()
+	}
+	for idx := range pending {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, e := range errors {
+		if e != nil && err == nil {
+			err = e
+		}
+	}
+
+	for _, isbn := range inputs {
+		books, ok := li.Cache.Get(keys[isbn])
+		switch {
+		case !ok:
+			res.Failed = append(res.Failed, isbn)
+		case len(books) == 0:
+			res.NotFound = append(res.NotFound, isbn)
+		case len(books) == 1:
+			res.Matches[isbn] = books[0]
+		default:
+			res.Ambiguous[isbn] = books
+		}
+	}
+
+	return
+}
+
+// toISBN13 returns the ISBN-13 of @isbn, or an empty string if @isbn is invalid.
+func toISBN13(isbn string) string {
+	isbn = NormalizeISBN(isbn)
+	if ValidISBN13(isbn) {
+		return isbn
+	}
+	isbn13, _ := ISBN10To13(isbn)
+	return isbn13
+}
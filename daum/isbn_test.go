@@ -0,0 +1,119 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum_test
+
+import (
+	"internal/common"
+	"math"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/daum"
+)
+
+func TestISBNConversion(t *testing.T) {
+	for isbn10, isbn13 := range map[string]string{
+		"0-306-40615-2": "9780306406157",
+		"080442957x":    "9780804429573",
+		"8996991341":    "9788996991342",
+	} {
+		if converted, err := daum.ISBN10To13(isbn10); err != nil || converted != isbn13 {
+			t.Errorf("%s: expected %s, got %s (%v)", isbn10, isbn13, converted, err)
+		}
+		if converted, err := daum.ISBN13To10(isbn13); err != nil || converted != daum.NormalizeISBN(isbn10) {
+			t.Errorf("%s: expected %s, got %s (%v)", isbn13, isbn10, converted, err)
+		}
+	}
+
+	if daum.ValidISBN10("0306406153") || daum.ValidISBN13("9780306406158") {
+		t.Error("invalid check digits are accepted")
+	}
+	if _, err := daum.ISBN13To10("9791162540640"); err != daum.ErrInvalidISBN {
+		t.Errorf("expected ErrInvalidISBN for a 979 prefix, got %v", err)
+	}
+}
+
+func TestBookResultAnalytics(t *testing.T) {
+	book := daum.BookResult{ISBN: " 9788996991342", Price: 20000, SalePrice: 18000, Status: "정상판매"}
+	if book.ISBN10() != "8996991341" || book.ISBN13() != "9788996991342" {
+		t.Errorf("unexpected ISBNs: %s, %s", book.ISBN10(), book.ISBN13())
+	}
+	if book.Discount() != 10 || !book.Available() {
+		t.Errorf("unexpected discount: %v", book.Discount())
+	}
+
+	results := daum.BookSearchResults{
+		{Documents: []daum.BookResult{
+			{ISBN: "8996991341 9788996991342", Publisher: "A", Authors: []string{"Kim", "Lee"}, Price: 20000, SalePrice: 18000},
+			{ISBN: "0306406152 9780306406157", Publisher: "A", Authors: []string{"Kim"}, Price: 10000, SalePrice: -1},
+		}},
+		{Documents: []daum.BookResult{
+			{ISBN: "8996991341 9788996991342", Publisher: "A", Authors: []string{"Kim", "Lee"}, Price: 20000, SalePrice: 18000},
+		}},
+	}
+
+	byPublisher := results.ByPublisher()
+	if stats := byPublisher["A"]; stats.Count != 2 || stats.MinPrice != 10000 || stats.MaxPrice != 20000 ||
+		stats.AveragePrice != 15000 || stats.AverageSalePrice != 18000 || math.Abs(stats.AverageDiscount-10) > 1e-9 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	byAuthor := results.ByAuthor()
+	if byAuthor["Kim"].Count != 2 || byAuthor["Lee"].Count != 1 {
+		t.Errorf("unexpected stats: %+v", byAuthor)
+	}
+}
+
+func TestLookupISBN(t *testing.T) {
+	res, err := daum.LookupISBN("978-89-969913-4-2").
+		AuthorizeWith(common.REST_API_KEY).
+		Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, book := range res.Documents {
+		t.Log(book.Title, book.ISBN13(), book.Discount())
+	}
+}
+
+func TestLookupISBNsCached(t *testing.T) {
+	cache := daum.NewMemoryBookCache()
+	cache.Set("9788996991342", []daum.BookResult{{Publisher: "A", ISBN: "8996991341 9788996991342"}})
+	cache.Set("9780306406157", []daum.BookResult{{Publisher: "B"}, {Publisher: "C"}})
+	cache.Set("9780804429573", nil)
+
+	res, err := daum.LookupISBNs("89-969913-4-1", "9780306406157", "080442957X", "12345", "12345", "080442957X").
+		CacheWith(cache).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Matches["89-969913-4-1"].Publisher != "A" || len(res.Ambiguous["9780306406157"]) != 2 {
+		t.Errorf("unexpected matches: %+v", res)
+	}
+	if len(res.NotFound) != 1 || res.NotFound[0] != "080442957X" || len(res.Invalid) != 1 || res.Invalid[0] != "12345" {
+		t.Errorf("unexpected misses: %v, %v", res.NotFound, res.Invalid)
+	}
+}
+
+func TestLookupISBNs(t *testing.T) {
+	res, err := daum.LookupISBNs("978-89-969913-4-2", "0-306-40615-2").
+		AuthorizeWith(common.REST_API_KEY).
+		Concurrency(2).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(res)
+}
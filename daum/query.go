@@ -0,0 +1,187 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum
+
+import (
+	"log"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxQueryLength is the maximum number of characters in a rendered query.
+//
+// The Daum search API documents no limit of its own, so this is a bound of the client,
+// which keeps a query built of many operators from growing into a request URL the API may reject.
+const MaxQueryLength = 200
+
+// Query is a builder of Daum search queries with the search operators.
+//
+// Pass the query to the Query variant of any Daum search constructor, e.g. BlogSearchQuery(Q("kakao").Site("tistory.com")),
+// which validates it before rendering.
+type Query struct {
+	terms    []string
+	excluded []string
+	sites    []string
+}
+
+// Q starts a query with @terms, which are all searched.
+func Q(terms ...string) *Query {
+	q := &Query{}
+	for _, term := range terms {
+		q.terms = append(q.terms, strings.Fields(term)...)
+	}
+	return q
+}
+
+// Phrase adds @phrase, which must appear exactly as it is.
+func (q *Query) Phrase(phrase string) *Query {
+	if phrase = strings.Join(strings.Fields(strings.ReplaceAll(phrase, `"`, "")), " "); phrase != "" {
+		q.terms = append(q.terms, `"`+phrase+`"`)
+	} else {
+		panic(ErrEmptyQuery)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return q
+}
+
+// Exclude drops the documents containing any of @terms.
+//
+// A term with spaces is excluded as a phrase.
+func (q *Query) Exclude(terms ...string) *Query {
+	for _, term := range terms {
+		if term = quote(term); term != "" {
+			q.excluded = append(q.excluded, "-"+term)
+		}
+	}
+	return q
+}
+
+// Site limits the documents to the ones on @domain.
+func (q *Query) Site(domain string) *Query {
+	domain = strings.TrimSpace(domain)
+	for _, scheme := range []string{"https://", "http://"} {
+		domain = strings.TrimPrefix(domain, scheme)
+	}
+	domain = strings.TrimSuffix(domain, "/")
+	if domain != "" && !strings.ContainsAny(domain, " \t\n/") {
+		q.sites = append(q.sites, "site:"+domain)
+	} else {
+		panic(ErrInvalidSite)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return q
+}
+
+// Or adds @alternatives, of which at least one is searched.
+//
+// An alternative with spaces is searched as a phrase.
+func (q *Query) Or(alternatives ...string) *Query {
+	var terms []string
+	for _, alt := range alternatives {
+		if alt = quote(alt); alt != "" {
+			terms = append(terms, alt)
+		}
+	}
+	if 0 < len(terms) {
+		q.terms = append(q.terms, strings.Join(terms, " | "))
+	}
+	return q
+}
+
+// String renders q as a query string.
+func (q *Query) String() string {
+	var parts []string
+	parts = append(parts, q.terms...)
+	parts = append(parts, q.excluded...)
+	parts = append(parts, q.sites...)
+	return strings.Join(parts, " ")
+}
+
+// Validate reports whether q renders a valid query.
+//
+// A query must have a term to search, and fit in MaxQueryLength characters.
+func (q *Query) Validate() error {
+	if len(q.terms) == 0 {
+		return ErrEmptyQuery
+	}
+	if MaxQueryLength < utf8.RuneCountInString(q.String()) {
+		return ErrQueryTooLong
+	}
+	return nil
+}
+
+// render returns q as a query string after validating it.
+func (q *Query) render() string {
+	if err := q.Validate(); err != nil {
+		panic(err)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return q.String()
+}
+
+// BlogSearchQuery allows to search blog posts by @q in the Daum Blog service.
+func BlogSearchQuery(q *Query) *BlogSearchIterator { return BlogSearch(q.render()) }
+
+// CafeSearchQuery allows to search cafe posts by @q in the Daum Cafe service.
+func CafeSearchQuery(q *Query) *CafeSearchIterator { return CafeSearch(q.render()) }
+
+// DocumentSearchQuery allows to search web documents by @q in the Daum search service.
+func DocumentSearchQuery(q *Query) *DocumentSearchIterator { return DocumentSearch(q.render()) }
+
+// ImageSearchQuery allows to search images by @q in the Daum search service.
+func ImageSearchQuery(q *Query) *ImageSearchIterator { return ImageSearch(q.render()) }
+
+// VideoSearchQuery allows to search videos by @q in the Daum search service.
+func VideoSearchQuery(q *Query) *VideoSearchIterator { return VideoSearch(q.render()) }
+
+// BookSearchQuery allows to search books by @q in the Daum Book service.
+func BookSearchQuery(q *Query) *BookSearchIterator { return BookSearch(q.render()) }
+
+// SearchAllQuery searches web documents, blog posts, cafe posts, videos, images and books by @q at once.
+func SearchAllQuery(q *Query) *SearchAllInitializer { return SearchAll(q.render()) }
+
+// WatchQuery watches new blog and cafe posts mentioning @q.
+func WatchQuery(q *Query) *Watcher { return Watch(q.render()) }
+
+// LookupISBNQuery searches the book with the ISBN of @q.
+func LookupISBNQuery(q *Query) *BookSearchIterator { return LookupISBN(q.render()) }
+
+// LookupISBNsQuery searches the books with the ISBNs of @queries in bulk.
+func LookupISBNsQuery(queries ...*Query) *ISBNLookupInitializer {
+	isbns := make([]string, len(queries))
+	for idx, q := range queries {
+		isbns[idx] = q.render()
+	}
+	return LookupISBNs(isbns...)
+}
+
+// quote returns @term, quoted if it has spaces.
+func quote(term string) string {
+	fields := strings.Fields(strings.ReplaceAll(term, `"`, ""))
+	switch len(fields) {
+	case 0:
+		return ""
+	case 1:
+		return fields[0]
+	default:
+		return `"` + strings.Join(fields, " ") + `"`
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum_test
+
+import (
+	"internal/common"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/daum"
+)
+
+func TestQuery(t *testing.T) {
+	q := daum.Q("카카오  맛집").
+		Phrase(`"판교 역"`).
+		Exclude("광고", "협찬 포함", " ").
+		Site("https://tistory.com/").
+		Or("파스타", "피자")
+
+	expected := `카카오 맛집 "판교 역" 파스타 | 피자 -광고 -"협찬 포함" site:tistory.com`
+	if q.String() != expected {
+		t.Errorf("expected %s, got %s", expected, q.String())
+	}
+	if err := q.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	if err := daum.Q().Exclude("광고").Validate(); err != daum.ErrEmptyQuery {
+		t.Errorf("expected ErrEmptyQuery, got %v", err)
+	}
+
+	if it := daum.BlogSearchQuery(q); it.Query != url.QueryEscape(expected) {
+		t.Errorf("expected %s, got %s", url.QueryEscape(expected), it.Query)
+	}
+	if w := daum.WatchQuery(q); w.Query != expected {
+		t.Errorf("expected %s, got %s", expected, w.Query)
+	}
+	if li := daum.LookupISBNsQuery(daum.Q("89-969913-4-1"), daum.Q("9780306406157")); len(li.ISBNs) != 2 || li.ISBNs[0] != "89-969913-4-1" {
+		t.Errorf("unexpected ISBNs: %v", li.ISBNs)
+	}
+
+	long := daum.Q(strings.Repeat("가", daum.MaxQueryLength-len("site:a.com"))).Site("a.com")
+	if err := long.Validate(); err != daum.ErrQueryTooLong {
+		t.Errorf("expected ErrQueryTooLong, got %v", err)
+	}
+	if err := daum.Q(strings.Repeat("가", daum.MaxQueryLength)).Validate(); err != nil {
+		t.Errorf("expected a valid query, got %v", err)
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected a panic on an empty query")
+		}
+	}()
+	daum.CafeSearchQuery(daum.Q("   "))
+}
+
+func TestBlogSearchWithQuery(t *testing.T) {
+	q := daum.Q("카카오").Exclude("광고").Site("tistory.com")
+
+	res, err := daum.BlogSearchQuery(q).
+		AuthorizeWith(common.REST_API_KEY).
+		Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(res)
+}
@@ -0,0 +1,247 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum
+
+import (
+	"internal/common"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Host returns the host of @rawurl without the www. prefix, or an empty string if it has none.
+func Host(rawurl string) string {
+	u, err := url.Parse(strings.TrimSpace(rawurl))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// GroupRank represents the rank positions of the documents in a group, such as a domain.
+//
+// Share is the percentage of the ranked documents in the group.
+type GroupRank struct {
+	Name        string  `json:"name"`
+	Count       int     `json:"count"`
+	BestRank    int     `json:"best_rank"`
+	AverageRank float64 `json:"average_rank"`
+	Share       float64 `json:"share"`
+}
+
+// Ranking represents the groups of ranked documents, from the one with the most documents.
+type Ranking []GroupRank
+
+// String implements fmt.Stringer.
+func (r Ranking) String() string { return common.String(r) }
+
+// SaveAs saves r to @filename.
+func (r Ranking) SaveAs(filename string) error { return common.SaveAsJSON(r, filename) }
+
+// Top returns the first @n groups of r.
+func (r Ranking) Top(n int) Ranking {
+	if n < len(r) {
+		return r[:n]
+	}
+	return r
+}
+
+// Positions returns the best rank of each group of r.
+func (r Ranking) Positions() map[string]int {
+	positions := make(map[string]int, len(r))
+	for _, gr := range r {
+		positions[gr.Name] = gr.BestRank
+	}
+	return positions
+}
+
+// rankGroups groups the documents by @names, the group names of the documents in rank order.
+func rankGroups(names []string) (r Ranking) {
+	index := map[string]int{}
+	for idx, name := range names {
+		if name == "" {
+			continue
+		}
+		pos, ok := index[name]
+		if !ok {
+			pos = len(r)
+			index[name] = pos
+			r = append(r, GroupRank{Name: name, BestRank: idx + 1})
+		}
+		gr := &r[pos]
+		gr.AverageRank = (gr.AverageRank*float64(gr.Count) + float64(idx+1)) / float64(gr.Count+1)
+		gr.Count++
+	}
+	for idx := range r {
+		r[idx].Share = float64(r[idx].Count) / float64(len(names)) * 100
+	}
+	sort.SliceStable(r, func(i, j int) bool {
+		if r[i].Count != r[j].Count {
+			return r[i].Count > r[j].Count
+		}
+		return r[i].BestRank < r[j].BestRank
+	})
+	return
+}
+
+// positions returns the first rank of each of @urls in rank order.
+func positions(urls []string) map[string]int {
+	ranks := map[string]int{}
+	for idx, u := range urls {
+		if _, ok := ranks[u]; !ok {
+			ranks[u] = idx + 1
+		}
+	}
+	return ranks
+}
+
+func hosts(urls []string) []string {
+	names := make([]string, len(urls))
+	for idx, u := range urls {
+		names[idx] = Host(u)
+	}
+	return names
+}
+
+func (drs DocumentSearchResults) urls() (urls []string) {
+	for _, dr := range drs {
+		for _, doc := range dr.Documents {
+			urls = append(urls, doc.URL)
+		}
+	}
+	return
+}
+
+// Positions returns the rank of each document of drs by its URL, counting across the pages.
+func (drs DocumentSearchResults) Positions() map[string]int { return positions(drs.urls()) }
+
+// ByHost ranks the documents of drs by their hosts.
+func (drs DocumentSearchResults) ByHost() Ranking { return rankGroups(hosts(drs.urls())) }
+
+func (brs BlogSearchResults) urls() (urls []string) {
+	for _, br := range brs {
+		for _, doc := range br.Documents {
+			urls = append(urls, doc.URL)
+		}
+	}
+	return
+}
+
+// Positions returns the rank of each post of brs by its URL, counting across the pages.
+func (brs BlogSearchResults) Positions() map[string]int { return positions(brs.urls()) }
+
+// ByHost ranks the posts of brs by their hosts.
+func (brs BlogSearchResults) ByHost() Ranking { return rankGroups(hosts(brs.urls())) }
+
+// ByBlog ranks the posts of brs by their blog names.
+func (brs BlogSearchResults) ByBlog() Ranking {
+	var names []string
+	for _, br := range brs {
+		for _, doc := range br.Documents {
+			names = append(names, doc.PlainBlogname())
+		}
+	}
+	return rankGroups(names)
+}
+
+func (crs CafeSearchResults) urls() (urls []string) {
+	for _, cr := range crs {
+		for _, doc := range cr.Documents {
+			urls = append(urls, doc.URL)
+		}
+	}
+	return
+}
+
+// Positions returns the rank of each post of crs by its URL, counting across the pages.
+func (crs CafeSearchResults) Positions() map[string]int { return positions(crs.urls()) }
+
+// ByHost ranks the posts of crs by their hosts.
+func (crs CafeSearchResults) ByHost() Ranking { return rankGroups(hosts(crs.urls())) }
+
+// ByCafe ranks the posts of crs by their cafe names.
+func (crs CafeSearchResults) ByCafe() Ranking {
+	var names []string
+	for _, cr := range crs {
+		for _, doc := range cr.Documents {
+			names = append(names, doc.PlainCafeName())
+		}
+	}
+	return rankGroups(names)
+}
+
+// RankChange represents the change of a rank between two snapshots.
+//
+// A zero Before or After means it was absent from the snapshot.
+type RankChange struct {
+	Key    string `json:"key"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+}
+
+// Delta returns the number of positions rc went up, or 0 if it is absent from either snapshot.
+func (rc RankChange) Delta() int {
+	if rc.Before == 0 || rc.After == 0 {
+		return 0
+	}
+	return rc.Before - rc.After
+}
+
+// CompareRanks compares the ranks of @before with the ones of @after,
+// such as the Positions of two search results.
+//
+// The changes are sorted by the rank after, followed by the dropped ones.
+func CompareRanks(before, after map[string]int) (changes []RankChange) {
+	for key, rank := range after {
+		changes = append(changes, RankChange{Key: key, Before: before[key], After: rank})
+	}
+	for key, rank := range before {
+		if _, ok := after[key]; !ok {
+			changes = append(changes, RankChange{Key: key, Before: rank})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		lhs, rhs := changes[i], changes[j]
+		if (lhs.After == 0) != (rhs.After == 0) {
+			return lhs.After != 0
+		}
+		if lhs.After != rhs.After {
+			return lhs.After < rhs.After
+		}
+		if lhs.Before != rhs.Before {
+			return lhs.Before < rhs.Before
+		}
+		return lhs.Key < rhs.Key
+	})
+	return
+}
+
+// LoadDocumentSearchResults loads the results saved by DocumentSearchResults.SaveAs from @filename.
+func LoadDocumentSearchResults(filename string) (drs DocumentSearchResults, err error) {
+	err = common.LoadJSON(&drs, filename)
+	return
+}
+
+// LoadBlogSearchResults loads the results saved by BlogSearchResults.SaveAs from @filename.
+func LoadBlogSearchResults(filename string) (brs BlogSearchResults, err error) {
+	err = common.LoadJSON(&brs, filename)
+	return
+}
+
+// LoadCafeSearchResults loads the results saved by CafeSearchResults.SaveAs from @filename.
+func LoadCafeSearchResults(filename string) (crs CafeSearchResults, err error) {
+	err = common.LoadJSON(&crs, filename)
+	return
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/daum"
+)
+
+func blogPost(url, blogname string) daum.BlogResult {
+	return daum.BlogResult{WebResult: daum.WebResult{URL: url}, Blogname: blogname}
+}
+
+func TestBlogRanking(t *testing.T) {
+	before := daum.BlogSearchResults{
+		{Documents: []daum.BlogResult{
+			blogPost("https://a.tistory.com/1", "A"),
+			blogPost("http://blog.naver.com/b/2", "<b>B</b>"),
+		}},
+		{Documents: []daum.BlogResult{
+			blogPost("https://www.A.tistory.com/3", "A"),
+		}},
+	}
+
+	ranking := before.ByHost()
+	if ranking[0].Name != "a.tistory.com" || ranking[0].Count != 2 || ranking[0].BestRank != 1 || ranking[0].AverageRank != 2 {
+		t.Errorf("unexpected ranking: %v", ranking)
+	}
+	if blogs := before.ByBlog(); len(blogs) != 2 || blogs[1].Name != "B" {
+		t.Errorf("unexpected ranking: %v", blogs)
+	}
+
+	filename := filepath.Join(t.TempDir(), "ranking_test.json")
+	if err := before.SaveAs(filename); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := daum.LoadBlogSearchResults(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after := daum.BlogSearchResults{
+		{Documents: []daum.BlogResult{
+			blogPost("https://www.A.tistory.com/3", "A"),
+			blogPost("https://a.tistory.com/1", "A"),
+			blogPost("https://c.tistory.com/4", "C"),
+		}},
+	}
+
+	changes := daum.CompareRanks(loaded.Positions(), after.Positions())
+	expected := []daum.RankChange{
+		{Key: "https://www.A.tistory.com/3", Before: 3, After: 1},
+		{Key: "https://a.tistory.com/1", Before: 1, After: 2},
+		{Key: "https://c.tistory.com/4", After: 3},
+		{Key: "http://blog.naver.com/b/2", Before: 2},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected %v, got %v", expected, changes)
+	}
+	if changes[0].Delta() != 2 || changes[2].Delta() != 0 {
+		t.Errorf("unexpected deltas: %v", changes)
+	}
+}
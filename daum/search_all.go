@@ -0,0 +1,319 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum
+
+import (
+	"internal/common"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The source types of a unified Daum search.
+const (
+	SourceWeb   = "web"
+	SourceBlog  = "blog"
+	SourceCafe  = "cafe"
+	SourceVClip = "vclip"
+	SourceImage = "image"
+	SourceBook  = "book"
+)
+
+// Document represents a document of a unified Daum search result.
+//
+// Rank is the position of the document in its source, starting from 1.
+// Snippet is empty for videos and images.
+type Document struct {
+	Source    string    `json:"source"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+	Snippet   string    `json:"snippet"`
+	Datetime  time.Time `json:"datetime"`
+	Thumbnail string    `json:"thumbnail"`
+	Rank      int       `json:"rank"`
+	Score     float64   `json:"score"`
+}
+
+// SearchAllResult represents a unified Daum search result.
+//
+// Totals holds the metadata of each source which responded.
+type SearchAllResult struct {
+	Totals    map[string]common.PageableMeta `json:"totals"`
+	Documents []Document                     `json:"documents"`
+}
+
+// String implements fmt.Stringer.
+func (sr SearchAllResult) String() string { return common.String(sr) }
+
+// SaveAs saves sr to @filename.
+func (sr SearchAllResult) SaveAs(filename string) error { return common.SaveAsJSON(sr, filename) }
+
+// SearchAllInitializer is a lazy unified Daum searcher.
+type SearchAllInitializer struct {
+	Query   string
+	Sort    string
+	Size    int
+	AuthKey string
+}
+
+// SearchAll searches web documents, blog posts, cafe posts, videos, images and books by @query at once.
+func SearchAll(query string) *SearchAllInitializer {
+	return &SearchAllInitializer{
+		Query:   strings.TrimSpace(query),
+		Sort:    "accuracy",
+		Size:    10,
+		AuthKey: common.KeyPrefix,
+	}
+}
+
+// AuthorizeWith sets the authorization key to @key.
+func (si *SearchAllInitializer) AuthorizeWith(key string) *SearchAllInitializer {
+	si.AuthKey = common.FormatKey(key)
+	return si
+}
+
+// SortBy sets the sorting order of the merged documents to @order.
+//
+// @order can be accuracy or recency. (default is accuracy)
+//
+// In the case of accuracy, the documents are ranked by a blend of their rank in each source and their freshness.
+func (si *SearchAllInitializer) SortBy(order string) *SearchAllInitializer {
+	switch order {
+	case "accuracy", "recency":
+		si.Sort = order
+	default:
+		panic(common.ErrUnsupportedSortingOrder)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return si
+}
+
+// Display sets the number of documents collected from each source (a value between 1 and 30).
+func (si *SearchAllInitializer) Display(size int) *SearchAllInitializer {
+	if 1 <= size && size <= 30 {
+		si.Size = size
+	} else {
+		panic(common.ErrSizeOutOfBound)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return si
+}
+
+// sourceResult is the first page of a source, normalized.
+type sourceResult struct {
+	source string
+	meta   common.PageableMeta
+	docs   []Document
+	err    error
+}
+
+// Collect searches all the sources concurrently and returns the merged result.
+//
+// A source which fails is left out of the result.
+// Collect returns an error only if every source fails.
+func (si *SearchAllInitializer) Collect() (res SearchAllResult, err error) {
+	searches := []func() sourceResult{si.web, si.blog, si.cafe, si.vclip, si.image, si.book}
+
+	var (
+		items = make([]sourceResult, len(searches))
+		wg    sync.WaitGroup
+	)
+
+	for idx, search := range searches {
+		wg.Add(1)
+		go func(idx int, search func() sourceResult) {
+			defer wg.Done()
+			items[idx] = search()
+		}(idx, search)
+	}
+	wg.Wait()
+
+	res.Totals = map[string]common.PageableMeta{}
+	for _, item := range items {
+		if item.err != nil {
+			if err == nil {
+				err = item.err
+			}
+			continue
+		}
+		res.Totals[item.source] = item.meta
+		res.Documents = append(res.Documents, item.docs...)
+	}
+	if 0 < len(res.Totals) {
+		err = nil
+	}
+
+	rank(res.Documents, si.Sort, time.Now())
+
+	return
+}
+
+// rank sorts @docs in @order.
+func rank(docs []Document, order string, now time.Time) {
+	for idx := range docs {
+		freshness := 0.0
+		if !docs[idx].Datetime.IsZero() {
+			days := now.Sub(docs[idx].Datetime).Hours() / 24
+			if days < 0 {
+				days = 0
+			}
+			freshness = 1 / (1 + days/30)
+		}
+		docs[idx].Score = 0.8/float64(docs[idx].Rank) + 0.2*freshness
+	}
+
+	sort.SliceStable(docs, func(i, j int) bool {
+		if order == "recency" || docs[i].Score == docs[j].Score {
+			return docs[i].Datetime.After(docs[j].Datetime)
+		}
+		return docs[i].Score > docs[j].Score
+	})
+}
+
+func (si *SearchAllInitializer) web() (sr sourceResult) {
+	it := DocumentSearch(si.Query).Display(si.Size)
+	it.AuthKey, it.Sort = si.AuthKey, si.Sort
+
+	res, err := it.Next()
+	sr = sourceResult{source: SourceWeb, meta: res.Meta, err: err}
+	for idx, doc := range res.Documents {
+		sr.docs = append(sr.docs, Document{
+			Source:   SourceWeb,
+			Title:    doc.Title,
+			URL:      doc.URL,
+			Snippet:  doc.Contents,
+			Datetime: doc.Datetime,
+			Rank:     idx + 1,
+		})
+	}
+	return
+}
+
+func (si *SearchAllInitializer) blog() (sr sourceResult) {
+	it := BlogSearch(si.Query).Display(si.Size)
+	it.AuthKey, it.Sort = si.AuthKey, si.Sort
+
+	res, err := it.Next()
+	sr = sourceResult{source: SourceBlog, meta: res.Meta, err: err}
+	for idx, doc := range res.Documents {
+		sr.docs = append(sr.docs, blogDocument(doc, idx+1))
+	}
+	return
+}
+
+// blogDocument normalizes @doc at @rank into a Document.
+func blogDocument(doc BlogResult, rank int) Document {
+	return Document{
+		Source:    SourceBlog,
+		Title:     doc.Title,
+		URL:       doc.URL,
+		Snippet:   doc.Contents,
+		Datetime:  doc.Datetime,
+		Thumbnail: doc.Thumbnail,
+		Rank:      rank,
+	}
+}
+
+func (si *SearchAllInitializer) cafe() (sr sourceResult) {
+	it := CafeSearch(si.Query).Display(si.Size)
+	it.AuthKey, it.Sort = si.AuthKey, si.Sort
+
+	res, err := it.Next()
+	sr = sourceResult{source: SourceCafe, meta: res.Meta, err: err}
+	for idx, doc := range res.Documents {
+		sr.docs = append(sr.docs, cafeDocument(doc, idx+1))
+	}
+	return
+}
+
+// cafeDocument normalizes @doc at @rank into a Document.
+func cafeDocument(doc CafeResult, rank int) Document {
+	return Document{
+		Source:    SourceCafe,
+		Title:     doc.Title,
+		URL:       doc.URL,
+		Snippet:   doc.Contents,
+		Datetime:  doc.Datetime,
+		Thumbnail: doc.Thumbnail,
+		Rank:      rank,
+	}
+}
+
+func (si *SearchAllInitializer) vclip() (sr sourceResult) {
+	it := VideoSearch(si.Query).Display(si.Size)
+	it.AuthKey, it.Sort = si.AuthKey, si.Sort
+
+	res, err := it.Next()
+	sr = sourceResult{source: SourceVClip, meta: res.Meta, err: err}
+	for idx, doc := range res.Documents {
+		sr.docs = append(sr.docs, Document{
+			Source:    SourceVClip,
+			Title:     doc.Title,
+			URL:       doc.URL,
+			Datetime:  doc.Datetime,
+			Thumbnail: doc.Thumbnail,
+			Rank:      idx + 1,
+		})
+	}
+	return
+}
+
+func (si *SearchAllInitializer) image() (sr sourceResult) {
+	it := ImageSearch(si.Query).Display(si.Size)
+	it.AuthKey, it.Sort = si.AuthKey, si.Sort
+
+	res, err := it.Next()
+	sr = sourceResult{source: SourceImage, meta: res.Meta, err: err}
+	for idx, doc := range res.Documents {
+		sr.docs = append(sr.docs, Document{
+			Source:    SourceImage,
+			Title:     doc.DisplaySitename,
+			URL:       doc.DocURL,
+			Datetime:  doc.Datetime,
+			Thumbnail: doc.ThumbnailURL,
+			Rank:      idx + 1,
+		})
+	}
+	return
+}
+
+func (si *SearchAllInitializer) book() (sr sourceResult) {
+	it := BookSearch(si.Query).Display(si.Size)
+	it.AuthKey = si.AuthKey
+	if si.Sort == "recency" {
+		it.Sort = "latest"
+	}
+
+	res, err := it.Next()
+	sr = sourceResult{source: SourceBook, meta: res.Meta, err: err}
+	for idx, doc := range res.Documents {
+		sr.docs = append(sr.docs, Document{
+			Source:    SourceBook,
+			Title:     doc.Title,
+			URL:       doc.URL,
+			Snippet:   doc.Contents,
+			Datetime:  doc.Datetime,
+			Thumbnail: doc.Thumbnail,
+			Rank:      idx + 1,
+		})
+	}
+	return
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum_test
+
+import (
+	"internal/common"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/daum"
+)
+
+func TestSearchAllWithJSON(t *testing.T) {
+	query := "Imitation Game"
+
+	res, err := daum.SearchAll(query).
+		AuthorizeWith(common.REST_API_KEY).
+		SortBy("accuracy").
+		Display(10).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for source, meta := range res.Totals {
+		t.Log(source, meta.TotalCount)
+	}
+	t.Log(res)
+}
+
+func TestSearchAllWithSaveAsJSON(t *testing.T) {
+	query := "Imitation Game"
+
+	res, err := daum.SearchAll(query).
+		AuthorizeWith(common.REST_API_KEY).
+		SortBy("recency").
+		Display(5).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for idx := 1; idx < len(res.Documents); idx++ {
+		if res.Documents[idx].Datetime.After(res.Documents[idx-1].Datetime) {
+			t.Errorf("documents are not sorted by recency")
+		}
+	}
+	if err := res.SaveAs("search_all_test.json"); err != nil {
+		t.Error(err)
+	}
+}
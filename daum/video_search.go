@@ -37,6 +37,23 @@ type VClipResult struct {
 	Author    string    `json:"author"`
 }
 
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// An empty or null datetime is decoded to the zero time.
+func (vr *VClipResult) UnmarshalJSON(data []byte) (err error) {
+	type shadow VClipResult
+	aux := struct {
+		shadow
+		Datetime string `json:"datetime"`
+	}{shadow: shadow(*vr)}
+	if err = json.Unmarshal(data, &aux); err != nil {
+		return
+	}
+	*vr = VClipResult(aux.shadow)
+	vr.Datetime, err = parseDatetime(aux.Datetime)
+	return
+}
+
 // VideoSearchResult represents a video search result.
 type VideoSearchResult struct {
 	Meta      common.PageableMeta `json:"meta"`
@@ -53,12 +70,18 @@ func (vrs VideoSearchResults) SaveAs(filename string) error { return common.Save
 
 // VideoSearchIterator is a lazy video search iterator.
 type VideoSearchIterator struct {
-	Query   string
-	Sort    string
-	Page    int
-	Size    int
-	AuthKey string
-	end     bool
+	Query     string
+	Sort      string
+	Page      int
+	Size      int
+	AuthKey   string
+	SinceTime time.Time
+	UntilTime time.Time
+	minimum   time.Duration
+	maximum   time.Duration
+	allowed   map[string]bool
+	denied    map[string]bool
+	end       bool
 }
 
 // VideoSearch allows users to search videos by @query on the video platforms such as Youtube or Kakao TV.
@@ -123,6 +146,88 @@ func (it *VideoSearchIterator) Display(size int) *VideoSearchIterator {
 	return it
 }
 
+// Since limits the documents to the ones posted at or after @t.
+//
+// When sorted by recency, the iterator stops paging once the documents get older than @t.
+// The documents without a datetime are kept, since they cannot be told out of the window.
+func (it *VideoSearchIterator) Since(t time.Time) *VideoSearchIterator {
+	it.SinceTime = t
+	return it
+}
+
+// Until limits the documents to the ones posted at or before @t.
+func (it *VideoSearchIterator) Until(t time.Time) *VideoSearchIterator {
+	it.UntilTime = t
+	return it
+}
+
+// MinDuration limits the videos to the ones playing for @d or longer.
+func (it *VideoSearchIterator) MinDuration(d time.Duration) *VideoSearchIterator {
+	if 0 <= d {
+		it.minimum = d
+	} else {
+		panic(ErrNegativeDuration)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return it
+}
+
+// MaxDuration limits the videos to the ones playing for @d or shorter.
+func (it *VideoSearchIterator) MaxDuration(d time.Duration) *VideoSearchIterator {
+	if 0 <= d {
+		it.maximum = d
+	} else {
+		panic(ErrNegativeDuration)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return it
+}
+
+// FromAuthors limits the videos to the ones uploaded by @authors.
+//
+// The authors are compared case-insensitively, and no authors means no author filter.
+func (it *VideoSearchIterator) FromAuthors(authors ...string) *VideoSearchIterator {
+	if it.allowed == nil {
+		it.allowed = map[string]bool{}
+	}
+	for _, author := range authors {
+		it.allowed[normalizeAuthor(author)] = true
+	}
+	return it
+}
+
+// ExcludeAuthors drops the videos uploaded by @authors.
+//
+// The authors are compared case-insensitively.
+func (it *VideoSearchIterator) ExcludeAuthors(authors ...string) *VideoSearchIterator {
+	if it.denied == nil {
+		it.denied = map[string]bool{}
+	}
+	for _, author := range authors {
+		it.denied[normalizeAuthor(author)] = true
+	}
+	return it
+}
+
+// accepts reports whether @doc passes the duration and author filters of it.
+func (it *VideoSearchIterator) accepts(doc VClipResult) bool {
+	d := doc.Duration()
+	if d < it.minimum || (0 < it.maximum && it.maximum < d) {
+		return false
+	}
+	author := normalizeAuthor(doc.Author)
+	if 0 < len(it.allowed) && !it.allowed[author] {
+		return false
+	}
+	return !it.denied[author]
+}
+
+func normalizeAuthor(author string) string { return strings.ToLower(strings.TrimSpace(author)) }
+
 // Next returns the video search result and proceeds the iterator to the next page.
 func (it *VideoSearchIterator) Next() (res VideoSearchResult, err error) {
 	if it.end {
@@ -154,7 +259,24 @@ func (it *VideoSearchIterator) Next() (res VideoSearchResult, err error) {
 		return
 	}
 
-	it.end = res.Meta.IsEnd || 15 < it.Page
+	var (
+		docs = res.Documents[:0]
+		last time.Time
+	)
+	for _, doc := range res.Documents {
+		if !doc.Datetime.IsZero() {
+			last = doc.Datetime
+		}
+		if within(doc.Datetime, it.SinceTime, it.UntilTime) && it.accepts(doc) {
+			docs = append(docs, doc)
+		}
+	}
+	res.Documents = docs
+
+	// the documents sorted by recency get older page by page, so the last dated one tells if the rest are too old
+	expired := it.Sort == "recency" && !last.IsZero() && last.Before(it.SinceTime)
+
+	it.end = res.Meta.IsEnd || expired || 15 < it.Page
 
 	it.Page++
 
@@ -162,7 +284,18 @@ func (it *VideoSearchIterator) Next() (res VideoSearchResult, err error) {
 }
 
 // CollectAll collects all the remaining video search results.
+//
+// When sorted by recency with Since, the pages are fetched one by one until the documents get older than the window.
 func (it *VideoSearchIterator) CollectAll() (results VideoSearchResults) {
+	if it.Sort == "recency" && !it.SinceTime.IsZero() {
+		for {
+			result, err := it.Next()
+			if err != nil {
+				return
+			}
+			results = append(results, result)
+		}
+	}
 
 	result, err := it.Next()
 	if err == nil {
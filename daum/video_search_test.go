@@ -17,6 +17,7 @@ package daum_test
 import (
 	"internal/common"
 	"testing"
+	"time"
 
 	"github.com/maengsanha/kakao-developers-client/daum"
 )
@@ -81,3 +82,45 @@ func TestVideoSearchCollectAll(t *testing.T) {
 		t.Log(item)
 	}
 }
+
+func TestVideoSearchFilters(t *testing.T) {
+	query := "major scale"
+
+	items := daum.VideoSearch(query).
+		AuthorizeWith(common.REST_API_KEY).
+		MinDuration(time.Minute).
+		MaxDuration(10 * time.Minute).
+		ExcludeAuthors("Kakao TV").
+		FromAuthors().
+		CollectAll()
+
+	for _, item := range items {
+		for _, doc := range item.Documents {
+			if d := doc.Duration(); d < time.Minute || 10*time.Minute < d {
+				t.Errorf("unexpected duration: %v", d)
+			}
+		}
+	}
+	t.Log(items.Stats())
+}
+
+func TestVideoSearchStats(t *testing.T) {
+	items := daum.VideoSearchResults{
+		{Documents: []daum.VClipResult{
+			{URL: "https://tv.kakao.com/1", PlayTime: 60, Author: "A"},
+			{URL: "https://tv.kakao.com/2", PlayTime: 120, Author: "B"},
+		}},
+		{Documents: []daum.VClipResult{
+			{URL: "https://tv.kakao.com/1", PlayTime: 60, Author: "A"},
+			{URL: "https://tv.kakao.com/3", PlayTime: 180, Author: "A"},
+		}},
+	}
+
+	stats := items.Stats()
+	if stats.Count != 3 || stats.TotalDuration != 6*time.Minute || stats.AverageDuration != 2*time.Minute {
+		t.Errorf("unexpected stats: %v", stats)
+	}
+	if stats.ByAuthor["A"] != 2 || stats.ByAuthor["B"] != 1 {
+		t.Errorf("unexpected authors: %v", stats.ByAuthor)
+	}
+}
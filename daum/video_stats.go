@@ -0,0 +1,57 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum
+
+import (
+	"internal/common"
+	"time"
+)
+
+// Duration returns the play time of vr.
+func (vr VClipResult) Duration() time.Duration { return time.Duration(vr.PlayTime) * time.Second }
+
+// VideoStats represents the statistics of a group of videos.
+type VideoStats struct {
+	Count           int            `json:"count"`
+	TotalDuration   time.Duration  `json:"total_duration"`
+	AverageDuration time.Duration  `json:"average_duration"`
+	ByAuthor        map[string]int `json:"by_author"`
+}
+
+// String implements fmt.Stringer.
+func (vs VideoStats) String() string { return common.String(vs) }
+
+// Stats returns the statistics of the videos of vrs.
+//
+// A video appearing more than once counts once.
+func (vrs VideoSearchResults) Stats() (stats VideoStats) {
+	stats.ByAuthor = map[string]int{}
+	seen := map[string]bool{}
+	for _, vr := range vrs {
+		for _, doc := range vr.Documents {
+			if seen[doc.URL] {
+				continue
+			}
+			seen[doc.URL] = true
+			stats.Count++
+			stats.TotalDuration += doc.Duration()
+			stats.ByAuthor[doc.Author]++
+		}
+	}
+	if 0 < stats.Count {
+		stats.AverageDuration = stats.TotalDuration / time.Duration(stats.Count)
+	}
+	return
+}
@@ -0,0 +1,335 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum
+
+import (
+	"context"
+	"internal/common"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// WatchState represents what a watcher has seen from a source.
+//
+// Newest is the datetime of the newest post, and Seen holds the URLs of the posts at or after it.
+type WatchState struct {
+	Newest time.Time            `json:"newest"`
+	Seen   map[string]time.Time `json:"seen"`
+}
+
+// StateStore is a pluggable store of watch states.
+//
+// Load returns a zero WatchState for a key which has never been saved.
+type StateStore interface {
+	Load(key string) (WatchState, error)
+	Save(key string, state WatchState) error
+}
+
+// MemoryStore is a StateStore kept in memory.
+type MemoryStore struct {
+	mu     sync.Mutex
+	states map[string]WatchState
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{states: map[string]WatchState{}}
+}
+
+// Load implements StateStore.
+func (ms *MemoryStore) Load(key string) (WatchState, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return ms.states[key], nil
+}
+
+// Save implements StateStore.
+func (ms *MemoryStore) Save(key string, state WatchState) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.states[key] = state
+	return nil
+}
+
+// FileStore is a StateStore persisted in a JSON file.
+type FileStore struct {
+	Filename string
+	mu       sync.Mutex
+}
+
+// NewFileStore returns a FileStore persisted in @filename.
+//
+// @filename should end with .json.
+func NewFileStore(filename string) *FileStore {
+	return &FileStore{Filename: filename}
+}
+
+func (fs *FileStore) load() (states map[string]WatchState, err error) {
+	states = map[string]WatchState{}
+	bs, err := ioutil.ReadFile(fs.Filename)
+	if os.IsNotExist(err) {
+		return states, nil
+	}
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(bs, &states)
+	return
+}
+
+// Load implements StateStore.
+func (fs *FileStore) Load(key string) (WatchState, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	states, err := fs.load()
+	return states[key], err
+}
+
+// Save implements StateStore.
+func (fs *FileStore) Save(key string, state WatchState) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	states, err := fs.load()
+	if err != nil {
+		return err
+	}
+	states[key] = state
+	return common.SaveAsJSON(states, fs.Filename)
+}
+
+// Watcher is a poller of new blog and cafe posts.
+type Watcher struct {
+	Query    string
+	Sources  []string
+	Interval time.Duration
+	Pages    int
+	AuthKey  string
+	Store    StateStore
+}
+
+// Watch watches new blog and cafe posts mentioning @query.
+//
+// On the first poll, every post on the first pages is new.
+func Watch(query string) *Watcher {
+	return &Watcher{
+		Query:    strings.TrimSpace(query),
+		Sources:  []string{SourceBlog, SourceCafe},
+		Interval: 10 * time.Minute,
+		Pages:    3,
+		AuthKey:  common.KeyPrefix,
+		Store:    NewMemoryStore(),
+	}
+}
+
+// AuthorizeWith sets the authorization key to @key.
+func (w *Watcher) AuthorizeWith(key string) *Watcher {
+	w.AuthKey = common.FormatKey(key)
+	return w
+}
+
+// On sets the sources to watch to @sources (blog or cafe).
+func (w *Watcher) On(sources ...string) *Watcher {
+	for _, source := range sources {
+		switch source {
+		case SourceBlog, SourceCafe:
+		default:
+			panic(ErrUnsupportedSource)
+		}
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	w.Sources = sources
+	return w
+}
+
+// Every sets the polling interval to @interval.
+func (w *Watcher) Every(interval time.Duration) *Watcher {
+	if 0 < interval {
+		w.Interval = interval
+	} else {
+		panic(ErrNonPositiveInterval)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return w
+}
+
+// Limit sets the maximum number of pages to look through per poll (a value between 1 and 50).
+func (w *Watcher) Limit(pages int) *Watcher {
+	if 1 <= pages && pages <= 50 {
+		w.Pages = pages
+	} else {
+		panic(common.ErrPageOutOfBound)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return w
+}
+
+// StoreIn sets the store of the watch states to @store.
+func (w *Watcher) StoreIn(store StateStore) *Watcher {
+	w.Store = store
+	return w
+}
+
+// Poll looks for new posts once, and returns them from the oldest.
+//
+// The states of the sources are saved only after all of them are polled,
+// so that no post is marked as seen unless it is returned.
+// If saving a state fails, the posts are returned along with the error.
+func (w *Watcher) Poll() (docs []Document, err error) {
+	var (
+		keys   = make([]string, len(w.Sources))
+		states = make([]WatchState, len(w.Sources))
+	)
+	for idx, source := range w.Sources {
+		var found []Document
+		keys[idx] = w.Query + "/" + source
+		if found, states[idx], err = w.poll(keys[idx], source); err != nil {
+			return nil, err
+		}
+		docs = append(docs, found...)
+	}
+
+	sort.SliceStable(docs, func(i, j int) bool {
+		return docs[i].Datetime.Before(docs[j].Datetime)
+	})
+
+	for idx, key := range keys {
+		if err = w.Store.Save(key, states[idx]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// poll returns the new posts of @source and its next state saved under @key.
+func (w *Watcher) poll(key, source string) (docs []Document, state WatchState, err error) {
+	if state, err = w.Store.Load(key); err != nil {
+		return
+	}
+
+	// the seen posts are copied, not to change the state in the store
+	seen := make(map[string]time.Time, len(state.Seen))
+	for url, datetime := range state.Seen {
+		seen[url] = datetime
+	}
+	state.Seen = seen
+
+	var next func() ([]Document, error)
+	switch source {
+	case SourceBlog:
+		it := BlogSearch(w.Query).SortBy("recency").Display(50).Since(state.Newest)
+		it.AuthKey = w.AuthKey
+		next = func() (docs []Document, err error) {
+			res, err := it.Next()
+			for idx, doc := range res.Documents {
+				docs = append(docs, blogDocument(doc, idx+1))
+			}
+			return
+		}
+	case SourceCafe:
+		it := CafeSearch(w.Query).SortBy("recency").Display(50).Since(state.Newest)
+		it.AuthKey = w.AuthKey
+		next = func() (docs []Document, err error) {
+			res, err := it.Next()
+			for idx, doc := range res.Documents {
+				docs = append(docs, cafeDocument(doc, idx+1))
+			}
+			return
+		}
+	default:
+		return nil, state, ErrUnsupportedSource
+	}
+
+	for page := 0; page < w.Pages; page++ {
+		found, err := next()
+		if err == Done {
+			break
+		}
+		if err != nil {
+			return nil, state, err
+		}
+		for _, doc := range found {
+			if _, ok := state.Seen[doc.URL]; ok {
+				continue
+			}
+			state.Seen[doc.URL] = doc.Datetime
+			docs = append(docs, doc)
+			if state.Newest.Before(doc.Datetime) {
+				state.Newest = doc.Datetime
+			}
+		}
+	}
+
+	// the posts before the newest one are filtered out by Since from now on, but the undated ones are not
+	for url, datetime := range state.Seen {
+		if !datetime.IsZero() && datetime.Before(state.Newest) {
+			delete(state.Seen, url)
+		}
+	}
+	return
+}
+
+// Run polls every interval until @ctx is done or a poll fails, and calls @handle with each new post.
+func (w *Watcher) Run(ctx context.Context, handle func(Document)) error {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		docs, err := w.Poll()
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			handle(doc)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Channel runs w in the background and sends each new post to the returned channel.
+//
+// The channel is closed when @ctx is done or a poll fails, and then the error is sent to errc.
+func (w *Watcher) Channel(ctx context.Context) (docs <-chan Document, errc <-chan error) {
+	docc, ec := make(chan Document), make(chan error, 1)
+
+	go func() {
+		defer close(docc)
+		ec <- w.Run(ctx, func(doc Document) {
+			select {
+			case docc <- doc:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return docc, ec
+}
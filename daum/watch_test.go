@@ -0,0 +1,126 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package daum_test
+
+import (
+	"fmt"
+	"internal/common"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maengsanha/kakao-developers-client/daum"
+)
+
+func TestFileStore(t *testing.T) {
+	store := daum.NewFileStore(filepath.Join(t.TempDir(), "watch_test.json"))
+
+	if state, err := store.Load("kakao/blog"); err != nil || !state.Newest.IsZero() {
+		t.Fatalf("expected a zero state, got %v, %v", state, err)
+	}
+
+	newest := time.Date(2022, 3, 1, 9, 0, 0, 0, time.UTC)
+	if err := store.Save("kakao/blog", daum.WatchState{
+		Newest: newest,
+		Seen:   map[string]time.Time{"https://blog.example.com/1": newest},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("kakao/cafe", daum.WatchState{Newest: newest.Add(-time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := daum.NewFileStore(store.Filename).Load("kakao/blog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Newest.Equal(newest) || len(state.Seen) != 1 {
+		t.Errorf("unexpected state: %+v", state)
+	}
+}
+
+// countingStore is a MemoryStore counting the saves.
+type countingStore struct {
+	*daum.MemoryStore
+	saves int
+}
+
+func (cs *countingStore) Save(key string, state daum.WatchState) error {
+	cs.saves++
+	return cs.MemoryStore.Save(key, state)
+}
+
+func TestWatchPollFailingSource(t *testing.T) {
+	failing := true
+	stubAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/cafe") && failing {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, `{"meta": {"is_end": true}, "documents": [{"url": "https://example.com%s", "datetime": "2022-03-01T10:00:00.000+09:00"}]}`, r.URL.Path)
+	})
+
+	store := &countingStore{MemoryStore: daum.NewMemoryStore()}
+	w := daum.Watch("kakao").StoreIn(store).Limit(1)
+
+	if docs, err := w.Poll(); err == nil || docs != nil || store.saves != 0 {
+		t.Fatalf("expected an error without saves, got %v, %v, %d saves", docs, err, store.saves)
+	}
+
+	// the posts of the source polled before the failing one are not lost
+	failing = false
+	docs, err := w.Poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != 2 || store.saves != 2 {
+		t.Errorf("expected the posts of both sources, got %v after %d saves", docs, store.saves)
+	}
+	if docs, err := w.Poll(); err != nil || len(docs) != 0 {
+		t.Errorf("expected no new posts, got %v, %v", docs, err)
+	}
+}
+
+func TestWatchPoll(t *testing.T) {
+	query := "카카오"
+
+	w := daum.Watch(query).
+		AuthorizeWith(common.REST_API_KEY).
+		On("blog", "cafe").
+		Limit(1)
+
+	docs, err := w.Poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(len(docs), "new posts")
+
+	seen := map[string]bool{}
+	for _, doc := range docs {
+		seen[doc.URL] = true
+	}
+
+	docs, err = w.Poll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, doc := range docs {
+		if seen[doc.URL] {
+			t.Errorf("post emitted twice: %s", doc.URL)
+		}
+	}
+}
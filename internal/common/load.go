@@ -0,0 +1,38 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+// LoadJSON loads @data saved by SaveAsJSON from @filename.
+//
+// @filename should end with .json.
+func LoadJSON(data interface{}, filename string) error {
+	switch tokens := strings.Split(filename, "."); tokens[len(tokens)-1] {
+	case "json":
+		bs, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(bs, data)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
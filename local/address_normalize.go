@@ -0,0 +1,322 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"encoding/xml"
+	"internal/common"
+	"regexp"
+	"strings"
+)
+
+// sidos maps the names and abbreviations of the metropolitan autonomous governments to their official names.
+var sidos = map[string]string{}
+
+func init() {
+	for name, aliases := range map[string][]string{
+		"서울특별시":   {"서울", "서울시"},
+		"부산광역시":   {"부산", "부산시"},
+		"대구광역시":   {"대구", "대구시"},
+		"인천광역시":   {"인천", "인천시"},
+		"광주광역시":   {"광주", "광주시"},
+		"대전광역시":   {"대전", "대전시"},
+		"울산광역시":   {"울산", "울산시"},
+		"세종특별자치시": {"세종", "세종시"},
+		"경기도":     {"경기"},
+		"강원특별자치도": {"강원", "강원도"},
+		"충청북도":    {"충북"},
+		"충청남도":    {"충남"},
+		"전북특별자치도": {"전북", "전라북도"},
+		"전라남도":    {"전남"},
+		"경상북도":    {"경북"},
+		"경상남도":    {"경남"},
+		"제주특별자치도": {"제주", "제주도"},
+	} {
+		sidos[name] = name
+		for _, alias := range aliases {
+			sidos[alias] = name
+		}
+	}
+}
+
+// CanonicalSido returns the official name of the metropolitan autonomous government @name,
+// or an empty string if @name is not one.
+func CanonicalSido(name string) string { return sidos[name] }
+
+var (
+	parenthesesPattern = regexp.MustCompile(`\(([^)]*)\)`)
+	roadNumberPattern  = regexp.MustCompile(`^(.+(?:로|길))(\d+(?:-\d+)?)$`)
+	dongNumberPattern  = regexp.MustCompile(`^(.+(?:동|가|리))(산?\d+(?:-\d+)?)$`)
+	numberPattern      = regexp.MustCompile(`^(산)?(\d+(?:-\d+)?)(?:번지|번)?$`)
+	subRoadPattern     = regexp.MustCompile(`^\d+번?길$`)
+)
+
+// ParsedAddress represents the structured components of a Korean address.
+//
+// Dong holds the eup, myeon or dong (and ri) of a jibun address,
+// and Road the road name of a road address.
+// BuildingNo is the building number of a road address, or the lot number of a jibun address.
+type ParsedAddress struct {
+	Sido       string `json:"sido" xml:"sido"`
+	Sigungu    string `json:"sigungu" xml:"sigungu"`
+	Dong       string `json:"dong" xml:"dong"`
+	Road       string `json:"road" xml:"road"`
+	BuildingNo string `json:"building_no" xml:"building_no"`
+	Detail     string `json:"detail" xml:"detail"`
+}
+
+// ParseAddress parses a user-entered Korean address @addr into its components.
+//
+// Unit details such as apartment building and room numbers end up in Detail.
+func ParseAddress(addr string) (pa ParsedAddress) {
+	// a parenthesized part of a road address usually holds the dong
+	var hint string
+	addr = parenthesesPattern.ReplaceAllStringFunc(addr, func(paren string) string {
+		if hint == "" {
+			inner := strings.Split(strings.Trim(paren, "()"), ",")[0]
+			if token := strings.TrimSpace(inner); strings.HasSuffix(token, "동") || strings.HasSuffix(token, "가") {
+				hint = token
+			}
+		}
+		return " "
+	})
+
+	var (
+		tokens   = normalizeTokens(strings.Fields(strings.ReplaceAll(addr, ",", " ")))
+		details  []string
+		mountain bool
+	)
+
+	for idx, token := range tokens {
+		switch {
+		case pa.BuildingNo != "":
+			details = append(details, token)
+		case idx == 0 && CanonicalSido(token) != "":
+			pa.Sido = CanonicalSido(token)
+		case token == "산":
+			mountain = true
+		case numberPattern.MatchString(token):
+			match := numberPattern.FindStringSubmatch(token)
+			pa.BuildingNo = match[2]
+			if match[1] != "" || mountain {
+				pa.BuildingNo = "산" + pa.BuildingNo
+			}
+		case pa.Road == "" && pa.Dong == "" && isSigungu(pa.Sigungu, token):
+			pa.Sigungu = strings.TrimSpace(pa.Sigungu + " " + token)
+		case pa.Road == "" && (strings.HasSuffix(token, "로") || strings.HasSuffix(token, "길")):
+			pa.Road = token
+		case pa.Road == "" && isDong(token):
+			pa.Dong = strings.TrimSpace(pa.Dong + " " + token)
+		default:
+			details = append(details, token)
+		}
+	}
+
+	if pa.Dong == "" {
+		pa.Dong = hint
+	}
+	pa.Detail = strings.Join(details, " ")
+	return
+}
+
+// normalizeTokens fixes the common spacing mistakes of @tokens.
+func normalizeTokens(tokens []string) (normalized []string) {
+	for _, token := range tokens {
+		last := len(normalized) - 1
+		switch {
+		// a detached suffix, e.g. 역삼 동
+		case 0 <= last && isSuffix(token):
+			normalized[last] += token
+		// a detached sub-road, e.g. 중앙로 10번길
+		case 0 <= last && subRoadPattern.MatchString(token) && strings.HasSuffix(normalized[last], "로"):
+			normalized[last] += token
+		// an attached number, e.g. 테헤란로152 or 역삼동737
+		case roadNumberPattern.MatchString(token):
+			match := roadNumberPattern.FindStringSubmatch(token)
+			normalized = append(normalized, match[1], match[2])
+		case dongNumberPattern.MatchString(token):
+			match := dongNumberPattern.FindStringSubmatch(token)
+			normalized = append(normalized, match[1], match[2])
+		default:
+			normalized = append(normalized, token)
+		}
+	}
+	return
+}
+
+func isSuffix(token string) bool {
+	switch token {
+	case "시", "군", "구", "읍", "면", "동", "리", "가", "로", "길":
+		return true
+	}
+	return false
+}
+
+func isSigungu(sigungu, token string) bool {
+	if !strings.HasSuffix(token, "시") && !strings.HasSuffix(token, "군") && !strings.HasSuffix(token, "구") {
+		return false
+	}
+	// a city may have autonomous districts, e.g. 성남시 분당구
+	return sigungu == "" || (strings.HasSuffix(sigungu, "시") && !strings.HasSuffix(token, "시"))
+}
+
+func isDong(token string) bool {
+	// a dong never starts with a number, unlike an apartment building such as 101동
+	if token == "" || ('0' <= token[0] && token[0] <= '9') {
+		return false
+	}
+	for _, suffix := range []string{"동", "가", "읍", "면", "리"} {
+		if strings.HasSuffix(token, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRoad reports whether pa is a road address.
+func (pa ParsedAddress) IsRoad() bool { return pa.Road != "" }
+
+// String returns the normalized address of pa without its detail.
+func (pa ParsedAddress) String() string {
+	if pa.IsRoad() {
+		return joinNonEmpty(pa.Sido, pa.Sigungu, pa.Road, pa.BuildingNo)
+	}
+	return joinNonEmpty(pa.Sido, pa.Sigungu, pa.Dong, pa.BuildingNo)
+}
+
+// Candidates returns the normalized variants of pa to search for, from the most specific one.
+//
+// The jibun variants are returned only for a jibun address, which has a lot number,
+// and an address of neither kind, such as a dong without a lot number, is returned as it is.
+func (pa ParsedAddress) Candidates() (candidates []string) {
+	seen := map[string]bool{}
+	add := func(tokens ...string) {
+		if candidate := joinNonEmpty(tokens...); candidate != "" && !seen[candidate] {
+			seen[candidate] = true
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	if pa.Road != "" {
+		add(pa.Sido, pa.Sigungu, pa.Road, pa.BuildingNo)
+		add(pa.Sigungu, pa.Road, pa.BuildingNo)
+		if pa.Sido != "" || pa.Sigungu != "" {
+			add(pa.Road, pa.BuildingNo)
+		}
+	}
+	// the building number of a road address is not a lot number
+	if pa.Dong != "" && pa.Road == "" && pa.BuildingNo != "" {
+		add(pa.Sido, pa.Sigungu, pa.Dong, pa.BuildingNo)
+		add(pa.Sigungu, pa.Dong, pa.BuildingNo)
+		if pa.Sido != "" || pa.Sigungu != "" {
+			add(pa.Dong, pa.BuildingNo)
+		}
+	}
+	if pa.Road == "" && (pa.Dong == "" || pa.BuildingNo == "") {
+		add(pa.Sido, pa.Sigungu, pa.Dong, pa.BuildingNo)
+	}
+	return
+}
+
+func joinNonEmpty(tokens ...string) string {
+	var nonEmpty []string
+	for _, token := range tokens {
+		if token != "" {
+			nonEmpty = append(nonEmpty, token)
+		}
+	}
+	return strings.Join(nonEmpty, " ")
+}
+
+// AddressMatch represents the result of an address normalization.
+//
+// Variant is the normalized address which matched Document.
+type AddressMatch struct {
+	XMLName  xml.Name       `json:"-" xml:"result"`
+	Input    string         `json:"input" xml:"input"`
+	Parsed   ParsedAddress  `json:"parsed" xml:"parsed"`
+	Variant  string         `json:"variant" xml:"variant"`
+	Document ComplexAddress `json:"document" xml:"document"`
+}
+
+// String implements fmt.Stringer.
+func (am AddressMatch) String() string { return common.String(am) }
+
+// SaveAs saves am to @filename.
+//
+// The file extension could be either .json or .xml.
+func (am AddressMatch) SaveAs(filename string) error {
+	return common.SaveAsJSONorXML(am, filename)
+}
+
+// AddressNormalizeInitializer is a lazy address normalizer.
+type AddressNormalizeInitializer struct {
+	Input   string
+	Parsed  ParsedAddress
+	AuthKey string
+}
+
+// NormalizeAddress parses a user-entered address @addr and searches for its normalized variants
+// until one of them matches.
+func NormalizeAddress(addr string) *AddressNormalizeInitializer {
+	addr = strings.Join(strings.Fields(addr), " ")
+	return &AddressNormalizeInitializer{
+		Input:   addr,
+		Parsed:  ParseAddress(addr),
+		AuthKey: common.KeyPrefix,
+	}
+}
+
+// AuthorizeWith sets the authorization key to @key.
+func (ni *AddressNormalizeInitializer) AuthorizeWith(key string) *AddressNormalizeInitializer {
+	ni.AuthKey = common.FormatKey(key)
+	return ni
+}
+
+// Collect returns the first address which matches one of the normalized variants.
+//
+// The input itself is tried last. If nothing matches, Collect returns ErrNoAddressMatch.
+func (ni *AddressNormalizeInitializer) Collect() (res AddressMatch, err error) {
+	res.Input, res.Parsed = ni.Input, ni.Parsed
+
+	candidates := ni.Parsed.Candidates()
+	if !contains(candidates, ni.Input) {
+		candidates = append(candidates, ni.Input)
+	}
+
+	for _, candidate := range candidates {
+		it := AddressSearch(candidate).Display(1)
+		it.AuthKey = ni.AuthKey
+
+		result, err := it.Next()
+		if err != nil {
+			return res, err
+		}
+		if 0 < len(result.Documents) {
+			res.Variant, res.Document = candidate, result.Documents[0]
+			return res, nil
+		}
+	}
+	return res, ErrNoAddressMatch
+}
+
+func contains(items []string, item string) bool {
+	for _, elem := range items {
+		if elem == item {
+			return true
+		}
+	}
+	return false
+}
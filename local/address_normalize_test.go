@@ -0,0 +1,88 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"internal/common"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/local"
+)
+
+func TestParseAddress(t *testing.T) {
+	for addr, expected := range map[string]local.ParsedAddress{
+		"서울시 강남구 테헤란로152, 101동 1203호 (역삼동)": {
+			Sido: "서울특별시", Sigungu: "강남구", Dong: "역삼동", Road: "테헤란로", BuildingNo: "152", Detail: "101동 1203호",
+		},
+		"경기 성남시 분당구 정자 동 178-1번지": {
+			Sido: "경기도", Sigungu: "성남시 분당구", Dong: "정자동", BuildingNo: "178-1",
+		},
+		"제주 제주시 애월읍 광령리 산 12": {
+			Sido: "제주특별자치도", Sigungu: "제주시", Dong: "애월읍 광령리", BuildingNo: "산12",
+		},
+		"부산 해운대구 센텀중앙로 10번길 25 3층": {
+			Sido: "부산광역시", Sigungu: "해운대구", Road: "센텀중앙로10번길", BuildingNo: "25", Detail: "3층",
+		},
+	} {
+		if parsed := local.ParseAddress(addr); parsed != expected {
+			t.Errorf("%s: expected %+v, got %+v", addr, expected, parsed)
+		}
+	}
+}
+
+func TestParsedAddressCandidates(t *testing.T) {
+	parsed := local.ParseAddress("서울 강남구 테헤란로 152 (역삼동)")
+
+	expected := []string{
+		"서울특별시 강남구 테헤란로 152",
+		"강남구 테헤란로 152",
+		"테헤란로 152",
+	}
+	checkCandidates(t, parsed.Candidates(), expected)
+
+	parsed = local.ParseAddress("서울 강남구 역삼동 737")
+	expected = []string{
+		"서울특별시 강남구 역삼동 737",
+		"강남구 역삼동 737",
+		"역삼동 737",
+	}
+	checkCandidates(t, parsed.Candidates(), expected)
+
+	// a dong without a lot number has no jibun variants
+	parsed = local.ParseAddress("서울 강남구 역삼동")
+	checkCandidates(t, parsed.Candidates(), []string{"서울특별시 강남구 역삼동"})
+}
+
+func checkCandidates(t *testing.T, candidates, expected []string) {
+	t.Helper()
+	if len(candidates) != len(expected) {
+		t.Fatalf("expected %q, got %q", expected, candidates)
+	}
+	for idx := range expected {
+		if candidates[idx] != expected[idx] {
+			t.Errorf("expected %q at %d, got %q", expected[idx], idx, candidates[idx])
+		}
+	}
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	res, err := local.NormalizeAddress("서울시 중구 을지로 65 SK T-타워 12층").
+		AuthorizeWith(common.REST_API_KEY).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(res)
+}
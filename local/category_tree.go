@@ -0,0 +1,157 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"internal/common"
+	"strings"
+)
+
+const categorySeparator = " > "
+
+// CategoryPath represents a parsed category hierarchy of a place, such as 음식점 > 한식 > 국밥.
+type CategoryPath []string
+
+// ParseCategoryPath parses @name into a category path.
+func ParseCategoryPath(name string) (path CategoryPath) {
+	for _, token := range strings.Split(name, ">") {
+		if token = strings.TrimSpace(token); token != "" {
+			path = append(path, token)
+		}
+	}
+	return
+}
+
+// String implements fmt.Stringer.
+func (cp CategoryPath) String() string { return strings.Join(cp, categorySeparator) }
+
+// HasPrefix reports whether cp is under @ancestor, or equal to it.
+func (cp CategoryPath) HasPrefix(ancestor CategoryPath) bool {
+	if len(ancestor) > len(cp) {
+		return false
+	}
+	for idx, name := range ancestor {
+		if cp[idx] != name {
+			return false
+		}
+	}
+	return true
+}
+
+// CategoryPath returns the parsed category hierarchy of p.
+func (p Place) CategoryPath() CategoryPath { return ParseCategoryPath(p.CategoryName) }
+
+// CategoryNode represents a node of a category taxonomy tree.
+//
+// Count is the number of places under the node.
+type CategoryNode struct {
+	Name     string          `json:"name" xml:"name"`
+	Path     string          `json:"path" xml:"path"`
+	Count    int             `json:"count" xml:"count"`
+	Children []*CategoryNode `json:"children,omitempty" xml:"children,omitempty"`
+	index    map[string]*CategoryNode
+}
+
+// NewCategoryTree builds a category taxonomy tree from @places and returns its root.
+func NewCategoryTree(places ...Place) *CategoryNode {
+	root := &CategoryNode{}
+	for _, place := range places {
+		root.add(place.CategoryPath())
+	}
+	return root
+}
+
+func (cn *CategoryNode) add(path CategoryPath) {
+	cn.Count++
+	if len(path) == 0 {
+		return
+	}
+	if cn.index == nil {
+		cn.index = map[string]*CategoryNode{}
+	}
+	child, ok := cn.index[path[0]]
+	if !ok {
+		child = &CategoryNode{Name: path[0], Path: path[0]}
+		if cn.Path != "" {
+			child.Path = cn.Path + categorySeparator + path[0]
+		}
+		cn.index[path[0]] = child
+		cn.Children = append(cn.Children, child)
+	}
+	child.add(path[1:])
+}
+
+// String implements fmt.Stringer.
+func (cn *CategoryNode) String() string { return common.String(cn) }
+
+// Find returns the node at @path (e.g. 음식점 > 한식), or nil if there is no such node.
+func (cn *CategoryNode) Find(path string) *CategoryNode {
+	node := cn
+	for _, name := range ParseCategoryPath(path) {
+		if node = node.index[name]; node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// Counts returns the number of places per category path under cn, including cn itself.
+func (cn *CategoryNode) Counts() map[string]int {
+	counts := map[string]int{}
+	var walk func(*CategoryNode)
+	walk = func(node *CategoryNode) {
+		counts[node.Path] = node.Count
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(cn)
+	return counts
+}
+
+// CategoryTree builds a category taxonomy tree from the documents of prs.
+//
+// Places which appear more than once are counted only once.
+func (prs PlaceSearchResults) CategoryTree() *CategoryNode {
+	var (
+		places []Place
+		seen   = map[string]bool{}
+	)
+	for _, pr := range prs {
+		for _, place := range pr.Documents {
+			if !seen[place.Id] {
+				seen[place.Id] = true
+				places = append(places, place)
+			}
+		}
+	}
+	return NewCategoryTree(places...)
+}
+
+// FilterByCategory returns the results of prs with only the places under @ancestor (e.g. 음식점 > 한식).
+func (prs PlaceSearchResults) FilterByCategory(ancestor string) (filtered PlaceSearchResults) {
+	path := ParseCategoryPath(ancestor)
+	for _, pr := range prs {
+		docs := []Place{}
+		for _, place := range pr.Documents {
+			if place.CategoryPath().HasPrefix(path) {
+				docs = append(docs, place)
+			}
+		}
+		pr.Documents = docs
+		filtered = append(filtered, pr)
+	}
+	return
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/local"
+)
+
+func TestParseCategoryPath(t *testing.T) {
+	path := local.ParseCategoryPath(" 음식점 >한식>  국밥 ")
+	if len(path) != 3 || path[0] != "음식점" || path[2] != "국밥" {
+		t.Errorf("unexpected path: %q", path)
+	}
+	if path.String() != "음식점 > 한식 > 국밥" {
+		t.Errorf("unexpected string: %s", path)
+	}
+	if !path.HasPrefix(local.ParseCategoryPath("음식점 > 한식")) {
+		t.Error("expected 음식점 > 한식 to be an ancestor")
+	}
+	if path.HasPrefix(local.ParseCategoryPath("음식점 > 양식")) {
+		t.Error("unexpected ancestor 음식점 > 양식")
+	}
+}
+
+func TestCategoryTree(t *testing.T) {
+	results := local.PlaceSearchResults{
+		{Documents: []local.Place{
+			{Id: "1", CategoryName: "음식점 > 한식 > 국밥"},
+			{Id: "2", CategoryName: "음식점 > 한식 > 냉면"},
+			{Id: "3", CategoryName: "음식점 > 양식"},
+		}},
+		{Documents: []local.Place{
+			{Id: "1", CategoryName: "음식점 > 한식 > 국밥"},
+			{Id: "4", CategoryName: "의료,건강 > 약국"},
+		}},
+	}
+
+	root := results.CategoryTree()
+	counts := root.Counts()
+	for path, count := range map[string]int{
+		"":              4,
+		"음식점":           3,
+		"음식점 > 한식":      2,
+		"음식점 > 한식 > 국밥": 1,
+		"의료,건강 > 약국":    1,
+	} {
+		if counts[path] != count {
+			t.Errorf("expected %d places under %q, got %d", count, path, counts[path])
+		}
+	}
+
+	if node := root.Find("음식점 > 한식"); node == nil || len(node.Children) != 2 {
+		t.Errorf("unexpected node: %v", node)
+	}
+	if node := root.Find("음식점 > 중식"); node != nil {
+		t.Errorf("unexpected node: %v", node)
+	}
+
+	filtered := results.FilterByCategory("음식점 > 한식")
+	if len(filtered) != 2 || len(filtered[0].Documents) != 2 || len(filtered[1].Documents) != 1 {
+		t.Errorf("unexpected filter result: %v", filtered)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"encoding/xml"
+	"internal/common"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const maxRadius = 20000
+
+// NearbyPlace represents a place with its distance from the center coordinates in meters.
+type NearbyPlace struct {
+	Place
+	Meters int `json:"meters" xml:"meters"`
+}
+
+// NearestResult represents a nearest places search result.
+//
+// Radius is the search radius in meters at which the places were found.
+type NearestResult struct {
+	XMLName   xml.Name      `json:"-" xml:"result"`
+	Radius    int           `json:"radius" xml:"radius"`
+	Documents []NearbyPlace `json:"documents" xml:"documents"`
+}
+
+// String implements fmt.Stringer.
+func (nr NearestResult) String() string { return common.String(nr) }
+
+// SaveAs saves nr to @filename.
+//
+// The file extension could be either .json or .xml.
+func (nr NearestResult) SaveAs(filename string) error {
+	return common.SaveAsJSONorXML(nr, filename)
+}
+
+// NearestInitializer is a lazy nearest places finder.
+type NearestInitializer struct {
+	Query   string
+	X       float64
+	Y       float64
+	Count   int
+	Radius  int
+	AuthKey string
+}
+
+// Nearest finds the @n places nearest to @x and @y that match @query.
+//
+// @query is either a category group code (e.g. PM9) or a keyword.
+//
+// The search radius starts small and doubles up to 20000 meters until @n places are found.
+func Nearest(query string, x, y float64, n int) *NearestInitializer {
+	if n < 1 {
+		panic(common.ErrSizeOutOfBound)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+
+	return &NearestInitializer{
+		Query:   strings.TrimSpace(query),
+		X:       x,
+		Y:       y,
+		Count:   n,
+		Radius:  500,
+		AuthKey: common.KeyPrefix,
+	}
+}
+
+// AuthorizeWith sets the authorization key to @key.
+func (ni *NearestInitializer) AuthorizeWith(key string) *NearestInitializer {
+	ni.AuthKey = common.FormatKey(key)
+	return ni
+}
+
+// StartWith sets the initial search radius to @radius (a value between 1 and 20000) in meters.
+func (ni *NearestInitializer) StartWith(radius int) *NearestInitializer {
+	if 1 <= radius && radius <= maxRadius {
+		ni.Radius = radius
+	} else {
+		panic(ErrRadiusOutOfBound)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return ni
+}
+
+// Collect returns the nearest places sorted by distance.
+//
+// If there are less places than requested within 20000 meters,
+// Collect returns all the found places with ErrTooFewPlaces.
+func (ni *NearestInitializer) Collect() (res NearestResult, err error) {
+	for radius := ni.Radius; ; radius *= 2 {
+		if maxRadius < radius {
+			radius = maxRadius
+		}
+
+		if res.Documents, err = ni.search(radius); err != nil {
+			return
+		}
+		res.Radius = radius
+
+		if ni.Count <= len(res.Documents) || radius == maxRadius {
+			break
+		}
+	}
+
+	sort.SliceStable(res.Documents, func(i, j int) bool {
+		return res.Documents[i].Meters < res.Documents[j].Meters
+	})
+
+	if len(res.Documents) < ni.Count {
+		err = ErrTooFewPlaces
+	} else {
+		res.Documents = res.Documents[:ni.Count]
+	}
+	return
+}
+
+// placeIterator is the common behavior of KeywordSearchIterator and CategorySearchIterator.
+type placeIterator interface {
+	Next() (PlaceSearchResult, error)
+}
+
+// search collects up to ni.Count places within @radius, nearest first.
+func (ni *NearestInitializer) search(radius int) (places []NearbyPlace, err error) {
+	var it placeIterator
+	if isCategoryGroupCode(ni.Query) {
+		category := PlaceSearchByCategory(ni.Query).
+			WithRadius(ni.X, ni.Y, radius).
+			SortBy("distance")
+		category.AuthKey = ni.AuthKey
+		it = category
+	} else {
+		keyword := PlaceSearchByKeyword(ni.Query).
+			WithCoordinates(ni.X, ni.Y).
+			WithRadius(radius).
+			SortBy("distance")
+		keyword.AuthKey = ni.AuthKey
+		it = keyword
+	}
+
+	seen := map[string]bool{}
+	for len(places) < ni.Count {
+		res, err := it.Next()
+		if err == Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, place := range res.Documents {
+			if seen[place.Id] {
+				continue
+			}
+			seen[place.Id] = true
+			places = append(places, NearbyPlace{Place: place, Meters: ni.distanceTo(place)})
+		}
+		if len(res.Documents) == 0 {
+			break
+		}
+	}
+	return
+}
+
+// distanceTo returns the distance from the center coordinates of ni to @place in meters.
+func (ni *NearestInitializer) distanceTo(place Place) int {
+	if meters, err := strconv.Atoi(place.Distance); err == nil {
+		return meters
+	}
+
+	// fall back to the great-circle distance
+	x, xerr := strconv.ParseFloat(place.X, 64)
+	y, yerr := strconv.ParseFloat(place.Y, 64)
+	if xerr != nil || yerr != nil {
+		return math.MaxInt32
+	}
+	return int(haversine(ni.X, ni.Y, x, y))
+}
+
+// haversine returns the great-circle distance between two WGS84 coordinates in meters.
+func haversine(x1, y1, x2, y2 float64) float64 {
+	const earthRadius = 6371008.8
+
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dy, dx := rad(y2-y1), rad(x2-x1)
+	a := math.Sin(dy/2)*math.Sin(dy/2) +
+		math.Cos(rad(y1))*math.Cos(rad(y2))*math.Sin(dx/2)*math.Sin(dx/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// isCategoryGroupCode reports whether @code is one of the supported category group codes.
+func isCategoryGroupCode(code string) bool {
+	switch code {
+	case "MT1", "CS2", "PS3", "SC4", "AC5", "PK6", "OL7", "SW8", "BK9",
+		"CT1", "AG2", "PO3", "AT4", "AD5", "FD6", "CE7", "HP8", "PM9":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"internal/common"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/local"
+)
+
+func TestNearestByCategory(t *testing.T) {
+	x := 127.06283102249932
+	y := 37.514322572335935
+
+	res, err := local.Nearest("PM9", x, y, 5).
+		AuthorizeWith(common.REST_API_KEY).
+		StartWith(100).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Documents) != 5 {
+		t.Errorf("expected 5 places, got %d", len(res.Documents))
+	}
+	for idx := 1; idx < len(res.Documents); idx++ {
+		if res.Documents[idx].Meters < res.Documents[idx-1].Meters {
+			t.Errorf("places are not sorted by distance: %v", res)
+		}
+	}
+	t.Log(res)
+}
+
+func TestNearestByKeywordWithSaveAsJSON(t *testing.T) {
+	x := 127.06283102249932
+	y := 37.514322572335935
+
+	res, err := local.Nearest("카카오프렌즈", x, y, 3).
+		AuthorizeWith(common.REST_API_KEY).
+		Collect()
+	if err != nil && err != local.ErrTooFewPlaces {
+		t.Fatal(err)
+	}
+	if err = res.SaveAs("nearest_test.json"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"encoding/xml"
+	"internal/common"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CenterDistance represents the distance of a place from the center coordinates of a search.
+type CenterDistance struct {
+	X        string `json:"x" xml:"x"`
+	Y        string `json:"y" xml:"y"`
+	Distance int    `json:"distance" xml:"distance"`
+}
+
+// MergedPlace represents a place merged from one or more place search results.
+type MergedPlace struct {
+	Place
+	Distances []CenterDistance `json:"distances" xml:"distances"`
+}
+
+// Nearest returns the shortest known distance of mp in meters.
+//
+// ok is false if mp has never been found by a search with center coordinates.
+func (mp MergedPlace) Nearest() (distance int, ok bool) {
+	for _, cd := range mp.Distances {
+		if !ok || cd.Distance < distance {
+			distance, ok = cd.Distance, true
+		}
+	}
+	return
+}
+
+// PlaceSet is a collection of places deduplicated by their Id.
+type PlaceSet struct {
+	XMLName   xml.Name      `json:"-" xml:"result"`
+	Documents []MergedPlace `json:"documents" xml:"documents"`
+	index     map[string]int
+}
+
+// NewPlaceSet returns an empty place set.
+func NewPlaceSet() *PlaceSet {
+	return &PlaceSet{index: map[string]int{}}
+}
+
+// String implements fmt.Stringer.
+func (ps *PlaceSet) String() string { return common.String(ps) }
+
+// SaveAs saves ps to @filename.
+//
+// The file extension could be either .json or .xml.
+func (ps *PlaceSet) SaveAs(filename string) error {
+	return common.SaveAsJSONorXML(ps, filename)
+}
+
+// Len returns the number of places in ps.
+func (ps *PlaceSet) Len() int { return len(ps.Documents) }
+
+// Get returns the place with @id.
+func (ps *PlaceSet) Get(id string) (mp MergedPlace, ok bool) {
+	idx, ok := ps.index[id]
+	if ok {
+		mp = ps.Documents[idx]
+	}
+	return
+}
+
+// Add adds the documents of @results to ps.
+//
+// @x and @y are the center coordinates which the results were searched with,
+// as set by WithCoordinates or WithRadius. Leave them empty if the search had no center.
+//
+// A place which is already in ps is merged: its empty fields are filled in
+// and the distance from the new center is recorded.
+func (ps *PlaceSet) Add(x, y string, results ...PlaceSearchResult) *PlaceSet {
+	for _, result := range results {
+		for _, place := range result.Documents {
+			ps.add(x, y, place)
+		}
+	}
+	return ps
+}
+
+// AddPlaces adds @places to ps, the same as Add.
+func (ps *PlaceSet) AddPlaces(x, y string, places ...Place) *PlaceSet {
+	for _, place := range places {
+		ps.add(x, y, place)
+	}
+	return ps
+}
+
+func (ps *PlaceSet) add(x, y string, place Place) {
+	if ps.index == nil {
+		ps.index = map[string]int{}
+	}
+
+	idx, ok := ps.index[place.Id]
+	if !ok {
+		idx = len(ps.Documents)
+		ps.index[place.Id] = idx
+		ps.Documents = append(ps.Documents, MergedPlace{Place: place})
+	} else {
+		ps.Documents[idx].merge(place)
+	}
+
+	// the distance is only given when the search had a center
+	if x == "" || y == "" || place.Distance == "" {
+		return
+	}
+	distance, err := strconv.Atoi(place.Distance)
+	if err != nil {
+		return
+	}
+
+	mp := &ps.Documents[idx]
+	for _, cd := range mp.Distances {
+		if cd.X == x && cd.Y == y {
+			return
+		}
+	}
+	mp.Distances = append(mp.Distances, CenterDistance{X: x, Y: y, Distance: distance})
+}
+
+// merge fills the empty fields of mp with the ones of @place.
+func (mp *MergedPlace) merge(place Place) {
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	fill(&mp.PlaceName, place.PlaceName)
+	fill(&mp.CategoryName, place.CategoryName)
+	fill(&mp.CategoryGroupCode, place.CategoryGroupCode)
+	fill(&mp.CategoryGroupName, place.CategoryGroupName)
+	fill(&mp.Phone, place.Phone)
+	fill(&mp.AddressName, place.AddressName)
+	fill(&mp.RoadAddressName, place.RoadAddressName)
+	fill(&mp.X, place.X)
+	fill(&mp.Y, place.Y)
+	fill(&mp.PlaceURL, place.PlaceURL)
+	fill(&mp.Distance, place.Distance)
+}
+
+// Union adds all the places of @other to ps.
+func (ps *PlaceSet) Union(other *PlaceSet) *PlaceSet {
+	for _, mp := range other.Documents {
+		ps.add("", "", mp.Place)
+		for _, cd := range mp.Distances {
+			ps.add(cd.X, cd.Y, Place{Id: mp.Id, Distance: strconv.Itoa(cd.Distance)})
+		}
+	}
+	return ps
+}
+
+// Filter returns a new place set with the places satisfying @keep.
+func (ps *PlaceSet) Filter(keep func(MergedPlace) bool) *PlaceSet {
+	filtered := NewPlaceSet()
+	for _, mp := range ps.Documents {
+		if keep(mp) {
+			filtered.index[mp.Id] = len(filtered.Documents)
+			filtered.Documents = append(filtered.Documents, mp)
+		}
+	}
+	return filtered
+}
+
+// InCategory returns a new place set with the places in one of @groupcodes.
+func (ps *PlaceSet) InCategory(groupcodes ...string) *PlaceSet {
+	return ps.Filter(func(mp MergedPlace) bool {
+		for _, groupcode := range groupcodes {
+			if mp.CategoryGroupCode == groupcode {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// NameContains returns a new place set with the places whose name contains @substr.
+func (ps *PlaceSet) NameContains(substr string) *PlaceSet {
+	return ps.Filter(func(mp MergedPlace) bool {
+		return strings.Contains(mp.PlaceName, substr)
+	})
+}
+
+// WithPhone returns a new place set with the places which have a phone number.
+func (ps *PlaceSet) WithPhone() *PlaceSet {
+	return ps.Filter(func(mp MergedPlace) bool {
+		return strings.TrimSpace(mp.Phone) != ""
+	})
+}
+
+// SortByDistance sorts the places of ps by their nearest known distance.
+//
+// Places without any known distance are placed at the end.
+func (ps *PlaceSet) SortByDistance() *PlaceSet {
+	sort.SliceStable(ps.Documents, func(i, j int) bool {
+		di, iok := ps.Documents[i].Nearest()
+		dj, jok := ps.Documents[j].Nearest()
+		if iok != jok {
+			return iok
+		}
+		return di < dj
+	})
+	ps.index = make(map[string]int, len(ps.Documents))
+	for idx, mp := range ps.Documents {
+		ps.index[mp.Id] = idx
+	}
+	return ps
+}
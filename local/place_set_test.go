@@ -0,0 +1,94 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"internal/common"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/local"
+)
+
+func TestPlaceSetMerge(t *testing.T) {
+	keyword := local.PlaceSearchResult{Documents: []local.Place{
+		{Id: "1", PlaceName: "온누리약국", CategoryGroupCode: "PM9", Distance: "300"},
+		{Id: "2", PlaceName: "카카오프렌즈", CategoryGroupCode: "", Phone: "02-000-0000", Distance: "120"},
+	}}
+	category := local.PlaceSearchResult{Documents: []local.Place{
+		{Id: "1", PlaceName: "온누리약국", CategoryGroupCode: "PM9", Phone: "02-111-1111", Distance: "80"},
+		{Id: "3", PlaceName: "하나약국", CategoryGroupCode: "PM9", Distance: "50"},
+	}}
+
+	ps := local.NewPlaceSet().
+		Add("127.06", "37.51", keyword).
+		Add("127.05", "37.50", category).
+		Add("127.05", "37.50", category)
+
+	if ps.Len() != 3 {
+		t.Fatalf("expected 3 places, got %d", ps.Len())
+	}
+
+	mp, ok := ps.Get("1")
+	if !ok {
+		t.Fatal("place 1 not found")
+	}
+	if mp.Phone != "02-111-1111" {
+		t.Errorf("expected merged phone, got %q", mp.Phone)
+	}
+	if len(mp.Distances) != 2 {
+		t.Errorf("expected 2 distances, got %v", mp.Distances)
+	}
+	if d, _ := mp.Nearest(); d != 80 {
+		t.Errorf("expected nearest distance 80, got %d", d)
+	}
+
+	sorted := ps.InCategory("PM9").WithPhone().SortByDistance()
+	if sorted.Len() != 1 || sorted.Documents[0].Id != "1" {
+		t.Errorf("unexpected filter result: %v", sorted)
+	}
+
+	ps.SortByDistance()
+	for idx, id := range []string{"3", "1", "2"} {
+		if ps.Documents[idx].Id != id {
+			t.Errorf("expected %s at %d, got %s", id, idx, ps.Documents[idx].Id)
+		}
+	}
+}
+
+func TestPlaceSetWithSaveAsXML(t *testing.T) {
+	x := 127.06283102249932
+	y := 37.514322572335935
+
+	keyword := local.PlaceSearchByKeyword("약국").
+		AuthorizeWith(common.REST_API_KEY).
+		WithCoordinates(x, y).
+		WithRadius(1000).
+		CollectAll()
+
+	category := local.PlaceSearchByCategory("PM9").
+		AuthorizeWith(common.REST_API_KEY).
+		WithRadius(x, y, 1000).
+		CollectAll()
+
+	ps := local.NewPlaceSet().
+		Add("127.06283102249932", "37.514322572335935", keyword...).
+		Add("127.06283102249932", "37.514322572335935", category...).
+		NameContains("약국").
+		SortByDistance()
+
+	if err := ps.SaveAs("place_set_test.xml"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"encoding/xml"
+	"internal/common"
+	"strings"
+)
+
+// codeLengths are the significant lengths of a 10-digit region code per depth.
+//
+// e.g. 1168010100 (서울특별시 강남구 역삼동) is under 1168000000 (강남구) and 1100000000 (서울특별시).
+var codeLengths = []int{0, 2, 5, 8, 10}
+
+// RegionNode represents a node of an administrative region tree.
+//
+// Depth is 1 for a sido, 2 for a sigungu, 3 for an eup, myeon or dong and 4 for a ri.
+// Count is the number of addresses or places counted under the node.
+type RegionNode struct {
+	Name     string        `json:"name" xml:"name"`
+	Depth    int           `json:"depth" xml:"depth"`
+	BCode    string        `json:"b_code,omitempty" xml:"b_code,omitempty"`
+	HCode    string        `json:"h_code,omitempty" xml:"h_code,omitempty"`
+	Count    int           `json:"count" xml:"count"`
+	Children []*RegionNode `json:"children,omitempty" xml:"children,omitempty"`
+	parent   *RegionNode
+	index    map[string]*RegionNode
+}
+
+// Parent returns the parent node of rn, or nil if rn is the root.
+func (rn *RegionNode) Parent() *RegionNode { return rn.parent }
+
+// Path returns the full name of rn, such as 서울특별시 강남구 역삼동.
+func (rn *RegionNode) Path() string {
+	if rn.parent == nil || rn.parent.Depth == 0 {
+		return rn.Name
+	}
+	return rn.parent.Path() + " " + rn.Name
+}
+
+func (rn *RegionNode) child(name string) *RegionNode {
+	if rn.index == nil {
+		rn.index = map[string]*RegionNode{}
+	}
+	child, ok := rn.index[name]
+	if !ok {
+		child = &RegionNode{Name: name, Depth: rn.Depth + 1, parent: rn}
+		rn.index[name] = child
+		rn.Children = append(rn.Children, child)
+	}
+	return child
+}
+
+// RegionTree is an in-memory administrative region tree,
+// from sidos through sigungus down to eups, myeons and dongs.
+type RegionTree struct {
+	XMLName xml.Name    `json:"-" xml:"result"`
+	Root    *RegionNode `json:"root" xml:"root"`
+	bcodes  map[string]*RegionNode
+	hcodes  map[string]*RegionNode
+}
+
+// NewRegionTree returns an empty region tree.
+func NewRegionTree() *RegionTree {
+	return &RegionTree{
+		Root:   &RegionNode{},
+		bcodes: map[string]*RegionNode{},
+		hcodes: map[string]*RegionNode{},
+	}
+}
+
+// String implements fmt.Stringer.
+func (rt *RegionTree) String() string { return common.String(rt) }
+
+// SaveAs saves rt to @filename.
+//
+// The file extension could be either .json or .xml.
+func (rt *RegionTree) SaveAs(filename string) error {
+	return common.SaveAsJSONorXML(rt, filename)
+}
+
+// Add adds @regions to rt.
+//
+// A region of type B is indexed by its legal code, and one of type H by its administrative code.
+func (rt *RegionTree) Add(regions ...Region) *RegionTree {
+	for _, region := range regions {
+		node := rt.Root
+		for _, name := range []string{
+			region.Region1depthName,
+			region.Region2depthName,
+			region.Region3depthName,
+			region.Region4depthName,
+		} {
+			if name = strings.TrimSpace(name); name == "" {
+				break
+			}
+			node = node.child(name)
+
+			code := region.Code
+			if node.Depth < len(codeLengths) && codeLengths[node.Depth] <= len(code) {
+				code = code[:codeLengths[node.Depth]] + strings.Repeat("0", len(code)-codeLengths[node.Depth])
+			}
+			switch region.RegionType {
+			case "B":
+				if node.BCode == "" {
+					node.BCode = code
+					rt.bcodes[code] = node
+				}
+			case "H":
+				if node.HCode == "" {
+					node.HCode = code
+					rt.hcodes[code] = node
+				}
+			}
+		}
+	}
+	return rt
+}
+
+// AddResults adds the documents of @results to rt.
+func (rt *RegionTree) AddResults(results ...CoordToDistrictResult) *RegionTree {
+	for _, result := range results {
+		rt.Add(result.Documents...)
+	}
+	return rt
+}
+
+// LookupBCode returns the region with the legal code @code, or nil if there is no such region.
+func (rt *RegionTree) LookupBCode(code string) *RegionNode { return rt.bcodes[code] }
+
+// LookupHCode returns the region with the administrative code @code, or nil if there is no such region.
+func (rt *RegionTree) LookupHCode(code string) *RegionNode { return rt.hcodes[code] }
+
+// Find returns the deepest region which @address starts with, or nil if there is no such region.
+//
+// The sido of @address may be abbreviated, such as 서울 강남구 역삼동 737.
+func (rt *RegionTree) Find(address string) *RegionNode {
+	tokens := strings.Fields(address)
+	if len(tokens) == 0 {
+		return nil
+	}
+	if sido := CanonicalSido(tokens[0]); sido != "" {
+		tokens[0] = sido
+	}
+
+	node, rest := rt.Root, strings.Join(tokens, " ")
+	for {
+		var next *RegionNode
+		for _, child := range node.Children {
+			if rest == child.Name || strings.HasPrefix(rest, child.Name+" ") {
+				// prefer the longest name, e.g. 성남시 분당구 over 성남시
+				if next == nil || len(next.Name) < len(child.Name) {
+					next = child
+				}
+			}
+		}
+		if next == nil {
+			break
+		}
+		node, rest = next, strings.TrimSpace(strings.TrimPrefix(rest, next.Name))
+	}
+
+	if node == rt.Root {
+		return nil
+	}
+	return node
+}
+
+// count increments the counts of @node and its ancestors.
+func (rt *RegionTree) count(node *RegionNode) {
+	for ; node != nil; node = node.parent {
+		node.Count++
+	}
+}
+
+// CountAddresses counts @addresses per region, and returns the number of addresses out of rt.
+//
+// An address is matched by its legal code first, then by its administrative code and its name.
+func (rt *RegionTree) CountAddresses(addresses ...ComplexAddress) (unmatched int) {
+	for _, address := range addresses {
+		node := rt.LookupBCode(address.Address.BCode)
+		if node == nil {
+			node = rt.LookupHCode(address.Address.HCode)
+		}
+		if node == nil {
+			node = rt.Find(address.AddressName)
+		}
+		if node == nil {
+			unmatched++
+			continue
+		}
+		rt.count(node)
+	}
+	return
+}
+
+// CountPlaces counts @places per region by their addresses, and returns the number of places out of rt.
+func (rt *RegionTree) CountPlaces(places ...Place) (unmatched int) {
+	for _, place := range places {
+		node := rt.Find(place.AddressName)
+		if node == nil {
+			node = rt.Find(place.RoadAddressName)
+		}
+		if node == nil {
+			unmatched++
+			continue
+		}
+		rt.count(node)
+	}
+	return
+}
+
+// ResetCounts resets the counts of all the regions of rt.
+func (rt *RegionTree) ResetCounts() {
+	var walk func(*RegionNode)
+	walk = func(node *RegionNode) {
+		node.Count = 0
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(rt.Root)
+}
+
+// Counts returns the counts of all the regions of rt by their full names.
+func (rt *RegionTree) Counts() map[string]int {
+	counts := map[string]int{}
+	var walk func(*RegionNode)
+	walk = func(node *RegionNode) {
+		counts[node.Path()] = node.Count
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(rt.Root)
+	return counts
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local_test
+
+import (
+	"internal/common"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/local"
+)
+
+func TestRegionTree(t *testing.T) {
+	tree := local.NewRegionTree().Add(
+		local.Region{RegionType: "B", Region1depthName: "서울특별시", Region2depthName: "강남구", Region3depthName: "역삼동", Code: "1168010100"},
+		local.Region{RegionType: "H", Region1depthName: "서울특별시", Region2depthName: "강남구", Region3depthName: "역삼1동", Code: "1168064000"},
+		local.Region{RegionType: "B", Region1depthName: "경기도", Region2depthName: "성남시 분당구", Region3depthName: "정자동", Code: "4113510300"},
+	)
+
+	if node := tree.LookupBCode("1168010100"); node == nil || node.Path() != "서울특별시 강남구 역삼동" {
+		t.Errorf("unexpected node: %v", node)
+	}
+	if node := tree.LookupHCode("1168064000"); node == nil || node.Name != "역삼1동" {
+		t.Errorf("unexpected node: %v", node)
+	}
+	if node := tree.LookupBCode("1168000000"); node == nil || node.Name != "강남구" || node.HCode != "1168000000" {
+		t.Errorf("unexpected node: %v", node)
+	}
+	if node := tree.Find("경기 성남시 분당구 정자동 178-1"); node == nil || node.Depth != 3 {
+		t.Errorf("unexpected node: %v", node)
+	}
+
+	var address local.ComplexAddress
+	address.Address.BCode = "1168010100"
+	if unmatched := tree.CountAddresses(address); unmatched != 0 {
+		t.Errorf("expected every address to match, got %d unmatched", unmatched)
+	}
+
+	unmatched := tree.CountPlaces(
+		local.Place{AddressName: "서울 강남구 역삼동 737"},
+		local.Place{AddressName: "경기 성남시 분당구 정자동 178-1"},
+		local.Place{AddressName: "부산 해운대구 우동 1"},
+	)
+	if unmatched != 1 {
+		t.Errorf("expected 1 unmatched place, got %d", unmatched)
+	}
+
+	counts := tree.Counts()
+	for path, count := range map[string]int{
+		"서울특별시":           2,
+		"서울특별시 강남구 역삼동":   2,
+		"서울특별시 강남구 역삼1동":  0,
+		"경기도 성남시 분당구 정자동": 1,
+	} {
+		if counts[path] != count {
+			t.Errorf("expected %d in %s, got %d", count, path, counts[path])
+		}
+	}
+
+	tree.ResetCounts()
+	if tree.Root.Count != 0 {
+		t.Errorf("expected counts to be reset, got %d", tree.Root.Count)
+	}
+}
+
+func TestRegionTreeWithCoordToDistrict(t *testing.T) {
+	tree := local.NewRegionTree()
+
+	for _, coord := range [][2]float64{
+		{127.1086228, 37.4012191},
+		{127.0276368, 37.4979502},
+	} {
+		cr, err := local.CoordToDistrict(coord[0], coord[1]).
+			AuthorizeWith(common.REST_API_KEY).
+			Collect()
+		if err != nil {
+			t.Fatal(err)
+		}
+		tree.AddResults(cr)
+	}
+
+	if err := tree.SaveAs("region_tree_test.json"); err != nil {
+		t.Error(err)
+	}
+}
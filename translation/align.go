@@ -0,0 +1,104 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"internal/common"
+	"strings"
+)
+
+// Paragraphs returns the translated paragraphs of tr.
+func (tr TranslateResult) Paragraphs() []string {
+	paragraphs := make([]string, len(tr.TranslatedText))
+	for idx, sentences := range tr.TranslatedText {
+		paragraphs[idx] = strings.Join(sentences, " ")
+	}
+	return paragraphs
+}
+
+// Sentences returns the translated sentences of tr, regardless of their paragraphs.
+func (tr TranslateResult) Sentences() []string { return flatten(tr.TranslatedText) }
+
+// SentencePair represents a source sentence and its translation.
+//
+// Paragraph is the index of the line of the source text the sentence belongs to.
+type SentencePair struct {
+	Paragraph int    `json:"paragraph"`
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+}
+
+// Alignment represents the sentence pairs of a source text and its translation.
+type Alignment []SentencePair
+
+// String implements fmt.Stringer.
+func (a Alignment) String() string { return common.String(a) }
+
+// SaveAs saves a to @filename.
+//
+// The file extension must be .json.
+func (a Alignment) SaveAs(filename string) error { return common.SaveAsJSON(a, filename) }
+
+// Align pairs each sentence of @source with its translation in @tr.
+//
+// When a paragraph has not as many sentences as its translation,
+// the whole paragraph is paired with its translation instead,
+// and so is the whole text when the paragraphs do not match.
+func Align(source string, tr TranslateResult) (a Alignment) {
+	lines := splitLines(source)
+
+	// the translations of the blank lines may be left out
+	var indexes []int
+	paragraphs := tr.TranslatedText
+	if len(paragraphs) != len(lines) {
+		paragraphs = nil
+		for _, sentences := range tr.TranslatedText {
+			if 0 < len(sentences) {
+				paragraphs = append(paragraphs, sentences)
+			}
+		}
+		for idx, line := range lines {
+			if strings.TrimSpace(line) != "" {
+				indexes = append(indexes, idx)
+			}
+		}
+		if len(paragraphs) != len(indexes) {
+			return Alignment{{Source: strings.TrimSpace(source), Target: tr.Text()}}
+		}
+	} else {
+		for idx := range lines {
+			indexes = append(indexes, idx)
+		}
+	}
+
+	for pos, idx := range indexes {
+		sources, targets := SplitSentences(lines[idx]), paragraphs[pos]
+		switch {
+		case len(sources) == 0 && len(targets) == 0:
+			continue
+		case len(sources) == len(targets):
+			for n := range sources {
+				a = append(a, SentencePair{Paragraph: idx, Source: sources[n], Target: targets[n]})
+			}
+		default:
+			a = append(a, SentencePair{
+				Paragraph: idx,
+				Source:    strings.TrimSpace(lines[idx]),
+				Target:    strings.Join(targets, " "),
+			})
+		}
+	}
+	return
+}
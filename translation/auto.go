@@ -0,0 +1,90 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import "strings"
+
+// Auto is the source language which makes the translator detect the language of the text.
+const Auto = "auto"
+
+// Languages is the list of the target languages of the Translation API.
+var Languages = []string{"kr", "en", "jp", "cn", "vi", "id", "ar", "bn", "de",
+	"es", "fr", "hi", "it", "ms", "nl", "pt", "ru", "th", "tr"}
+
+// supported reports whether @code is one of Languages.
+func supported(code string) bool {
+	for _, lang := range Languages {
+		if code == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// TranslateCode returns the translation language code of @code, a detected language code.
+//
+// Both the codes of the Translation API (e.g. kr) and the ISO 639-1 codes (e.g. ko) are accepted.
+func TranslateCode(code string) (string, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	switch {
+	case code == "ko":
+		return "kr", true
+	case code == "ja":
+		return "jp", true
+	case code == "zh" || strings.HasPrefix(code, "zh-"):
+		return "cn", true
+	}
+	if supported(code) {
+		return code, true
+	}
+	return "", false
+}
+
+// detect detects the language of @text, and returns its translation language code.
+//
+// The language with the highest confidence is picked, as long as it is above the threshold of ti.
+func (ti *TranslateInitializer) detect(text string) (string, *LanguageInfo, error) {
+	di := Detect(text)
+	di.Authkey = ti.AuthKey
+
+	dr, err := di.Collect()
+	if err != nil {
+		return "", nil, err
+	}
+
+	top := topLanguage(dr.LanguageInfo)
+	if top == nil || top.Confidence < ti.Threshold {
+		return "", nil, ErrUndetectedLanguage
+	}
+
+	code, ok := TranslateCode(top.Code)
+	if !ok {
+		return "", top, ErrUnsupportedLanguage
+	}
+	return code, top, nil
+}
+
+// untranslated returns @text in the form of translated text.
+func untranslated(text string) [][]string {
+	lines := splitLines(text)
+	paragraphs := make([][]string, len(lines))
+	for idx, line := range lines {
+		paragraphs[idx] = SplitSentences(line)
+		if paragraphs[idx] == nil {
+			paragraphs[idx] = []string{}
+		}
+	}
+	return paragraphs
+}
@@ -0,0 +1,516 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"internal/common"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BatchDetectItem represents the language detected from a text of a batch.
+//
+// Language is nil if the text has no letters or its detection failed, in which case Error holds the reason.
+// Local reports whether the language is detected from the script of the text without calling the API.
+type BatchDetectItem struct {
+	Text     string        `json:"text"`
+	Language *LanguageInfo `json:"language,omitempty"`
+	Local    bool          `json:"local,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// BatchDetectResult represents a batch language detection result.
+//
+// Items are in the order of the texts, and Languages holds the number of the texts by language code.
+type BatchDetectResult struct {
+	Items     []BatchDetectItem `json:"items"`
+	Languages map[string]int    `json:"languages"`
+}
+
+// String implements fmt.Stringer.
+func (br BatchDetectResult) String() string { return common.String(br) }
+
+// SaveAs saves br to @filename.
+//
+// The file extension must be .json.
+func (br BatchDetectResult) SaveAs(filename string) error { return common.SaveAsJSON(br, filename) }
+
+// BatchDetectInitializer is a lazy language detector of many texts.
+type BatchDetectInitializer struct {
+	Texts    []string
+	Authkey  string
+	Share    float64
+	Workers  int
+	Interval time.Duration
+}
+
+// DetectBatch detects the language of each of @texts.
+//
+// The same texts are detected only once, and so are the ones differing only in spaces.
+// A text mostly written in a script of a single language, such as Hangul, is detected locally.
+// The other texts written partly in such a script, e.g. with RemoteOnly, are packed by the script
+// into requests of up to MaxLength characters, and detected one by one if a request does not clearly
+// detect the language of the script. The rest, such as the texts in Latin, are detected one by one.
+func DetectBatch(texts ...string) *BatchDetectInitializer {
+	return &BatchDetectInitializer{
+		Texts:    texts,
+		Authkey:  common.KeyPrefix,
+		Share:    0.9,
+		Workers:  4,
+		Interval: 100 * time.Millisecond,
+	}
+}
+
+// AuthorizeWith sets the authorization key to @key.
+func (bi *BatchDetectInitializer) AuthorizeWith(key string) *BatchDetectInitializer {
+	bi.Authkey = common.FormatKey(key)
+	return bi
+}
+
+// ScriptShare sets the minimum share of the letters in the script of a language
+// for a text to be detected locally to @share (a value between 0.5 and 1).
+func (bi *BatchDetectInitializer) ScriptShare(share float64) *BatchDetectInitializer {
+	if 0.5 <= share && share <= 1 {
+		bi.Share = share
+	} else {
+		panic(ErrShareOutOfBound)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return bi
+}
+
+// RemoteOnly makes the detector detect every text by the API.
+func (bi *BatchDetectInitializer) RemoteOnly() *BatchDetectInitializer {
+	bi.Share = 0
+	return bi
+}
+
+// Concurrency sets the number of workers sending the requests to @workers (a value between 1 and 32).
+func (bi *BatchDetectInitializer) Concurrency(workers int) *BatchDetectInitializer {
+	if 1 <= workers && workers <= 32 {
+		bi.Workers = workers
+	} else {
+		panic(ErrWorkersOutOfBound)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return bi
+}
+
+// RateLimit limits the requests to @n per second.
+func (bi *BatchDetectInitializer) RateLimit(n int) *BatchDetectInitializer {
+	if 0 < n {
+		bi.Interval = time.Second / time.Duration(n)
+	} else {
+		panic(ErrNonPositiveRate)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return bi
+}
+
+// Collect returns the batch language detection result.
+//
+// If some of the detections fail, the first error is returned along with the other results.
+func (bi *BatchDetectInitializer) Collect() (res BatchDetectResult, err error) {
+	res.Items = make([]BatchDetectItem, len(bi.Texts))
+	res.Languages = map[string]int{}
+
+	// the items of the same text share a detection
+	var (
+		uniques []string
+		index   = map[string]int{}
+		owners  = make([]int, len(bi.Texts))
+	)
+	for idx, text := range bi.Texts {
+		res.Items[idx].Text = text
+		key := normalizeSegment(text)
+		pos, ok := index[key]
+		if !ok {
+			pos = len(uniques)
+			index[key] = pos
+			uniques = append(uniques, key)
+		}
+		owners[idx] = pos
+	}
+
+	var (
+		detected = make([]BatchDetectItem, len(uniques))
+		errs     = make([]error, len(uniques))
+		remote   []int
+	)
+	for pos, text := range uniques {
+		if strings.IndexFunc(text, unicode.IsLetter) < 0 {
+			continue
+		}
+		if info, ok := detectScript(text, bi.Share); ok && 0 < bi.Share {
+			detected[pos].Language, detected[pos].Local = &info, true
+			continue
+		}
+		remote = append(remote, pos)
+	}
+
+	var (
+		ticker = time.NewTicker(bi.Interval)
+		jobs   = make(chan []int)
+		wg     sync.WaitGroup
+	)
+	defer ticker.Stop()
+
+	fail := func(pos int, err error) { detected[pos].Error, errs[pos] = err.Error(), err }
+	for worker := 0; worker < bi.Workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pack := range jobs {
+				if 1 < len(pack) {
+					texts := make([]string, len(pack))
+					for idx, pos := range pack {
+						texts[idx] = uniques[pos]
+					}
+					<-ticker.C
+					infos, err := bi.detect(strings.Join(texts, "\n"))
+					if err != nil {
+						for _, pos := range pack {
+							fail(pos, err)
+						}
+						continue
+					}
+					if shared := sharedLanguage(infos, packLanguage(uniques[pack[0]])); shared != nil {
+						// the confidence of each text is scaled by the share of the script in it
+						for _, pos := range pack {
+							info := *shared
+							share, _ := detectScript(uniques[pos], 0)
+							info.Confidence *= share.Confidence
+							detected[pos].Language = &info
+						}
+						continue
+					}
+				}
+
+				// the texts of an ambiguous pack are detected one by one
+				for _, pos := range pack {
+					<-ticker.C
+					infos, err := bi.detect(head(uniques[pos], MaxLength))
+					if err != nil {
+						fail(pos, err)
+						continue
+					}
+					detected[pos].Language = topLanguage(infos)
+				}
+			}
+		}()
+	}
+	for _, pack := range packTexts(uniques, remote) {
+		jobs <- pack
+	}
+	close(jobs)
+	wg.Wait()
+
+	for idx := range res.Items {
+		item := detected[owners[idx]]
+		item.Text = bi.Texts[idx]
+		res.Items[idx] = item
+		if item.Language != nil {
+			res.Languages[item.Language.Code]++
+		}
+	}
+	for _, e := range errs {
+		if e != nil {
+			return res, e
+		}
+	}
+	return
+}
+
+// detect returns the languages detected from @text.
+func (bi *BatchDetectInitializer) detect(text string) ([]LanguageInfo, error) {
+	di := Detect(text)
+	di.Authkey = bi.Authkey
+	dr, err := di.Collect()
+	return dr.LanguageInfo, err
+}
+
+// packedConfidence is the minimum confidence of the language detected from a pack of texts,
+// and packedMargin is its minimum lead over the other languages, for the texts to share the language.
+const (
+	packedConfidence = 0.8
+	packedMargin     = 0.5
+)
+
+// sharedLanguage returns the language of @infos detected from a pack of texts in the script of @code,
+// or nil if it is not the language of the script or not clearly ahead of the others.
+func sharedLanguage(infos []LanguageInfo, code string) *LanguageInfo {
+	top := topLanguage(infos)
+	if top == nil || top.Code != code || top.Confidence < packedConfidence {
+		return nil
+	}
+	for _, info := range infos {
+		if info.Code != top.Code && top.Confidence-info.Confidence < packedMargin {
+			return nil
+		}
+	}
+	return top
+}
+
+// packLanguage returns the code of the language whose script most of the letters of @text are written in,
+// or an empty string if the script is not of a single language, such as Latin.
+func packLanguage(text string) string {
+	info, _ := detectScript(text, 0)
+	return info.Code
+}
+
+// packTexts packs the texts of @texts at @positions in the script of the same language
+// into the packs of up to MaxLength characters joined by line breaks.
+//
+// A text not in such a script or longer than MaxLength makes a pack by itself.
+func packTexts(texts []string, positions []int) (packs [][]int) {
+	var (
+		codes  []string
+		groups = map[string][]int{}
+	)
+	for _, pos := range positions {
+		code := packLanguage(texts[pos])
+		if code == "" {
+			packs = append(packs, []int{pos})
+			continue
+		}
+		if _, ok := groups[code]; !ok {
+			codes = append(codes, code)
+		}
+		groups[code] = append(groups[code], pos)
+	}
+
+	for _, code := range codes {
+		var (
+			pack   []int
+			length int
+		)
+		for _, pos := range groups[code] {
+			n := utf8.RuneCountInString(texts[pos])
+			if 0 < len(pack) && MaxLength < length+1+n {
+				packs = append(packs, pack)
+				pack, length = nil, 0
+			}
+			if 0 < len(pack) {
+				length++
+			}
+			pack, length = append(pack, pos), length+n
+		}
+		if 0 < len(pack) {
+			packs = append(packs, pack)
+		}
+	}
+	return
+}
+
+// scriptLanguages are the languages detected locally by their scripts.
+var scriptLanguages = []struct {
+	code, name string
+}{
+	{"kr", "Korean"},
+	{"jp", "Japanese"},
+	{"th", "Thai"},
+}
+
+// detectScript detects the language of @text by its script,
+// if the letters in the script of a language take at least @share of the letters.
+//
+// The Chinese characters count as Japanese along with Hiragana or Katakana.
+func detectScript(text string, share float64) (LanguageInfo, bool) {
+	var letters, hangul, kana, han, thai int
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Thai, r):
+			thai++
+		}
+	}
+	if letters == 0 {
+		return LanguageInfo{}, false
+	}
+	if kana == 0 {
+		han = 0
+	}
+
+	best, count := 0, 0
+	for idx, n := range []int{hangul, kana + han, thai} {
+		if count < n {
+			best, count = idx, n
+		}
+	}
+	confidence := float64(count) / float64(letters)
+	if count == 0 || confidence < share {
+		return LanguageInfo{}, false
+	}
+	return LanguageInfo{
+		Code:       scriptLanguages[best].code,
+		Name:       scriptLanguages[best].name,
+		Confidence: confidence,
+	}, true
+}
+
+// topLanguage returns the language of @infos with the highest confidence.
+func topLanguage(infos []LanguageInfo) (top *LanguageInfo) {
+	for idx, info := range infos {
+		if top == nil || top.Confidence < info.Confidence {
+			top = &infos[idx]
+		}
+	}
+	return
+}
+
+// LanguageSegment represents the consecutive lines of a text in the same language.
+//
+// Start and End are the indexes of the first line and the line after the last one,
+// and the confidence of Language is averaged over the letters of the lines.
+type LanguageSegment struct {
+	Start    int           `json:"start"`
+	End      int           `json:"end"`
+	Text     string        `json:"text"`
+	Language *LanguageInfo `json:"language,omitempty"`
+}
+
+// SegmentDetectResult represents the language segments of a text.
+type SegmentDetectResult struct {
+	Segments []LanguageSegment `json:"segments"`
+}
+
+// String implements fmt.Stringer.
+func (sr SegmentDetectResult) String() string { return common.String(sr) }
+
+// SaveAs saves sr to @filename.
+//
+// The file extension must be .json.
+func (sr SegmentDetectResult) SaveAs(filename string) error { return common.SaveAsJSON(sr, filename) }
+
+// SegmentDetectInitializer is a lazy language detector of mixed-language texts.
+type SegmentDetectInitializer struct {
+	Text  string
+	Batch *BatchDetectInitializer
+}
+
+// DetectSegments splits @text into the segments in different languages.
+//
+// The language of each line is detected as a batch, and the consecutive lines in the same language
+// are joined into a segment. The lines without letters belong to the segment before them.
+func DetectSegments(text string) *SegmentDetectInitializer {
+	return &SegmentDetectInitializer{
+		Text:  text,
+		Batch: DetectBatch(splitLines(text)...),
+	}
+}
+
+// AuthorizeWith sets the authorization key to @key.
+func (si *SegmentDetectInitializer) AuthorizeWith(key string) *SegmentDetectInitializer {
+	si.Batch.AuthorizeWith(key)
+	return si
+}
+
+// ScriptShare sets the minimum share of the letters in the script of a language
+// for a line to be detected locally to @share (a value between 0.5 and 1).
+func (si *SegmentDetectInitializer) ScriptShare(share float64) *SegmentDetectInitializer {
+	si.Batch.ScriptShare(share)
+	return si
+}
+
+// RemoteOnly makes the detector detect every line by the API.
+func (si *SegmentDetectInitializer) RemoteOnly() *SegmentDetectInitializer {
+	si.Batch.RemoteOnly()
+	return si
+}
+
+// Concurrency sets the maximum number of concurrent requests to @workers (a value between 1 and 32).
+func (si *SegmentDetectInitializer) Concurrency(workers int) *SegmentDetectInitializer {
+	si.Batch.Concurrency(workers)
+	return si
+}
+
+// RateLimit limits the requests to @n per second.
+func (si *SegmentDetectInitializer) RateLimit(n int) *SegmentDetectInitializer {
+	si.Batch.RateLimit(n)
+	return si
+}
+
+// Collect returns the language segments of the text.
+func (si *SegmentDetectInitializer) Collect() (res SegmentDetectResult, err error) {
+	br, err := si.Batch.Collect()
+	if err != nil {
+		return
+	}
+
+	var (
+		lines  []string
+		weight float64
+		cur    *LanguageSegment
+	)
+	flush := func() {
+		if cur != nil {
+			if cur.Language != nil && 0 < weight {
+				cur.Language.Confidence /= weight
+			}
+			cur.Text = strings.Join(lines, "\n")
+			res.Segments = append(res.Segments, *cur)
+		}
+		lines, weight, cur = nil, 0, nil
+	}
+
+	for idx, item := range br.Items {
+		if cur != nil && item.Language != nil && (cur.Language == nil || cur.Language.Code != item.Language.Code) {
+			if cur.Language == nil {
+				// the lines without a language so far join the first segment with one
+				info := *item.Language
+				info.Confidence = 0
+				cur.Language = &info
+			} else {
+				flush()
+			}
+		}
+		if cur == nil {
+			cur = &LanguageSegment{Start: idx}
+			if item.Language != nil {
+				info := *item.Language
+				info.Confidence = 0
+				cur.Language = &info
+			}
+		}
+		if item.Language != nil {
+			n := float64(utf8.RuneCountInString(item.Text))
+			cur.Language.Confidence += item.Language.Confidence * n
+			weight += n
+		}
+		lines = append(lines, item.Text)
+		cur.End = idx + 1
+	}
+	flush()
+	return
+}
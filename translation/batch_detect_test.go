@@ -0,0 +1,126 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation_test
+
+import (
+	"fmt"
+	"internal/common"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/translation"
+)
+
+func TestDetectBatchLocally(t *testing.T) {
+	br, err := translation.DetectBatch("좋아요!", "좋아요 !", "すごい日本語", "ดีมาก", "123").Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for idx, code := range []string{"kr", "kr", "jp", "th"} {
+		if item := br.Items[idx]; item.Language == nil || item.Language.Code != code || !item.Local {
+			t.Errorf("expected %s, got %+v", code, item)
+		}
+	}
+	if br.Items[4].Language != nil || br.Languages["kr"] != 2 {
+		t.Errorf("unexpected result: %v", br)
+	}
+
+	sr, err := translation.DetectSegments("\n안녕하세요.\n반갑습니다.\n\n日本語です。\n---").Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sr.Segments) != 2 || sr.Segments[0].End != 4 || sr.Segments[0].Language.Code != "kr" ||
+		sr.Segments[1].Text != "日本語です。\n---" || sr.Segments[1].Language.Code != "jp" {
+		t.Errorf("unexpected segments: %v", sr)
+	}
+}
+
+// stubTransport serves the requests by a handler instead of the network.
+type stubTransport http.HandlerFunc
+
+func (st stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	st(rec, req)
+	return rec.Result(), nil
+}
+
+func TestDetectBatchPacks(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		queries []string
+	)
+	transport := http.DefaultTransport
+	http.DefaultTransport = stubTransport(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("query")
+		mu.Lock()
+		queries = append(queries, query)
+		mu.Unlock()
+
+		// a pack is detected as its majority language
+		code := "en"
+		switch {
+		case strings.Contains(query, "좋"):
+			code = "kr"
+		case !strings.Contains(query, "\n") && strings.Contains(query, "géniale"):
+			code = "fr"
+		}
+		fmt.Fprintf(w, `{"language_info": [{"code": %q, "name": %q, "confidence": 0.9}]}`, code, code)
+	})
+	defer func() { http.DefaultTransport = transport }()
+
+	br, err := translation.DetectBatch("Great app!", "Nice one", "Application géniale !", "Awesome", "좋아요", "좋아요 app").
+		RemoteOnly().
+		Concurrency(3).
+		RateLimit(1000).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the texts in Latin are detected one by one, and the ones in Hangul as a pack
+	for idx, code := range []string{"en", "en", "fr", "en", "kr", "kr"} {
+		if item := br.Items[idx]; item.Language == nil || item.Language.Code != code {
+			t.Errorf("expected %s, got %+v", code, item)
+		}
+	}
+	if confidence := br.Items[5].Language.Confidence; confidence != 0.9*0.5 {
+		t.Errorf("expected the confidence scaled by the share of Hangul, got %v", confidence)
+	}
+	if len(queries) != 5 {
+		t.Errorf("expected 5 requests, got %q", queries)
+	}
+}
+
+func TestDetectBatch(t *testing.T) {
+	br, err := translation.DetectBatch("Great app!", "Application géniale !", "좋아요!", "Great app!").
+		AuthorizeWith(common.REST_API_KEY).
+		RateLimit(5).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(br)
+
+	sr, err := translation.DetectSegments("안녕하세요.\nHello there.\nBonjour à tous.").
+		AuthorizeWith(common.REST_API_KEY).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(sr)
+}
@@ -0,0 +1,183 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SplitSentences splits @text into sentences.
+//
+// A sentence ends with a run of terminal punctuation followed by a space,
+// or directly by a non-Latin letter, as in Korean text such as "보여준다.다만".
+func SplitSentences(text string) (sentences []string) {
+	runes := []rune(text)
+	start := 0
+	for idx := 0; idx < len(runes); idx++ {
+		if !isTerminal(runes[idx]) {
+			continue
+		}
+		end := idx + 1
+		for end < len(runes) && (isTerminal(runes[end]) || isClosing(runes[end])) {
+			end++
+		}
+		if end == len(runes) || unicode.IsSpace(runes[end]) || isFullWidthTerminal(runes[idx]) ||
+			(runes[end] > unicode.MaxASCII && unicode.IsLetter(runes[end])) {
+			if sentence := strings.TrimSpace(string(runes[start:end])); sentence != "" {
+				sentences = append(sentences, sentence)
+			}
+			start = end
+		}
+		idx = end - 1
+	}
+	if sentence := strings.TrimSpace(string(runes[start:])); sentence != "" {
+		sentences = append(sentences, sentence)
+	}
+	return
+}
+
+func isTerminal(r rune) bool {
+	switch r {
+	case '.', '!', '?', '…':
+		return true
+	}
+	return isFullWidthTerminal(r)
+}
+
+func isFullWidthTerminal(r rune) bool {
+	switch r {
+	case '。', '！', '？':
+		return true
+	}
+	return false
+}
+
+func isClosing(r rune) bool {
+	switch r {
+	case '"', '\'', ')', ']', '}', '”', '’', '」', '』', '）':
+		return true
+	}
+	return false
+}
+
+// chunk is a piece of a document translated in a single request.
+type chunk struct {
+	text    string
+	lines   []int
+	partial bool
+}
+
+// splitLines splits @text into its lines without the trailing carriage returns.
+func splitLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	for idx, line := range lines {
+		lines[idx] = strings.TrimRight(line, "\r")
+	}
+	return lines
+}
+
+// chunkLines packs the non-blank @lines into chunks of up to @size characters.
+//
+// The lines are packed as a whole as long as they fit, and the longer ones are
+// split at the sentence boundaries into partial chunks.
+func chunkLines(lines []string, size int) (chunks []chunk) {
+	var (
+		cur    chunk
+		length int
+	)
+	flush := func() {
+		if 0 < len(cur.lines) {
+			chunks = append(chunks, cur)
+		}
+		cur, length = chunk{}, 0
+	}
+
+	for idx, line := range lines {
+		line = strings.TrimSpace(line)
+		n := utf8.RuneCountInString(line)
+		switch {
+		case n == 0:
+			continue
+		case size < n:
+			flush()
+			for _, piece := range pack(SplitSentences(line), size) {
+				chunks = append(chunks, chunk{text: piece, lines: []int{idx}, partial: true})
+			}
+		default:
+			if 0 < len(cur.lines) && size < length+1+n {
+				flush()
+			}
+			if 0 < len(cur.lines) {
+				cur.text += "\n"
+				length++
+			}
+			cur.text += line
+			cur.lines = append(cur.lines, idx)
+			length += n
+		}
+	}
+	flush()
+	return
+}
+
+// pack joins @sentences with spaces into pieces of up to @size characters.
+//
+// A sentence longer than @size is cut, preferably at a space.
+func pack(sentences []string, size int) (pieces []string) {
+	var (
+		cur    []string
+		length int
+	)
+	for _, sentence := range sentences {
+		for size < utf8.RuneCountInString(sentence) {
+			var head string
+			head, sentence = cut(sentence, size)
+			if 0 < len(cur) {
+				pieces = append(pieces, strings.Join(cur, " "))
+				cur, length = nil, 0
+			}
+			pieces = append(pieces, head)
+		}
+		n := utf8.RuneCountInString(sentence)
+		if 0 < len(cur) && size < length+1+n {
+			pieces = append(pieces, strings.Join(cur, " "))
+			cur, length = nil, 0
+		}
+		if 0 < len(cur) {
+			length++
+		}
+		cur = append(cur, sentence)
+		length += n
+	}
+	if 0 < len(cur) {
+		pieces = append(pieces, strings.Join(cur, " "))
+	}
+	return
+}
+
+// cut cuts the first @size characters of @s, at its last space if any.
+func cut(s string, size int) (head, tail string) {
+	runes := []rune(s)
+	end := size
+	for idx := size; 0 < idx; idx-- {
+		if unicode.IsSpace(runes[idx]) {
+			end = idx
+			break
+		}
+	}
+	return strings.TrimSpace(string(runes[:end])), strings.TrimSpace(string(runes[end:]))
+}
@@ -15,13 +15,13 @@
 package translation
 
 import (
-	"errors"
 	"fmt"
 	"internal/common"
 	"log"
 	"net/http"
 	"net/url"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/goccy/go-json"
 )
@@ -56,8 +56,8 @@ type DetectInitializer struct {
 //
 // See https://developers.kakao.com/docs/latest/ko/translate/dev-guide#language-detect for more details.
 func Detect(text string) *DetectInitializer {
-	if 5000 < len(text) {
-		panic(errors.New("up to 5,000 characters are allowed"))
+	if MaxLength < utf8.RuneCountInString(strings.TrimSpace(text)) {
+		panic(ErrTooLongText)
 	}
 	if r := recover(); r != nil {
 		log.Panicln(r)
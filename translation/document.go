@@ -0,0 +1,281 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"internal/common"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DocumentTranslateInitializer is a lazy translator of texts longer than MaxLength.
+type DocumentTranslateInitializer struct {
+	Text       string
+	Translator TranslateInitializer
+	Size       int
+	Workers    int
+	Interval   time.Duration
+}
+
+// TranslateDocument translates @text of any length.
+//
+// The text is split at the paragraph and sentence boundaries into chunks
+// translated concurrently, and the translations are reassembled in the original order
+// with a paragraph per line of @text.
+func TranslateDocument(text string) *DocumentTranslateInitializer {
+	return &DocumentTranslateInitializer{
+		Text:       text,
+		Translator: TranslateInitializer{AuthKey: common.KeyPrefix},
+		Size:       MaxLength,
+		Workers:    4,
+		Interval:   100 * time.Millisecond,
+	}
+}
+
+// AuthorizeWith sets the authorization key to @key.
+func (di *DocumentTranslateInitializer) AuthorizeWith(key string) *DocumentTranslateInitializer {
+	di.Translator.AuthorizeWith(key)
+	return di
+}
+
+// From sets the source language of the text to @src.
+//
+// See TranslateInitializer.From for the available languages.
+func (di *DocumentTranslateInitializer) From(src string) *DocumentTranslateInitializer {
+	di.Translator.From(src)
+	return di
+}
+
+// To sets the target language of the text to @target.
+//
+// See TranslateInitializer.To for the available languages.
+func (di *DocumentTranslateInitializer) To(target string) *DocumentTranslateInitializer {
+	di.Translator.To(target)
+	return di
+}
+
+// MinConfidence sets the minimum confidence of the language detected from Auto to @confidence (a value between 0 and 1).
+func (di *DocumentTranslateInitializer) MinConfidence(confidence float64) *DocumentTranslateInitializer {
+	di.Translator.MinConfidence(confidence)
+	return di
+}
+
+// WithGlossary makes the translator translate the terms of @g as it specifies.
+//
+// See TranslateInitializer.WithGlossary for more details.
+func (di *DocumentTranslateInitializer) WithGlossary(g *Glossary) *DocumentTranslateInitializer {
+	di.Translator.WithGlossary(g)
+	return di
+}
+
+// WithMemory makes the translator look up each line of the text in @tm before calling the API,
+// and add the translations of the other lines to @tm afterwards.
+//
+// See TranslateInitializer.WithMemory for more details.
+func (di *DocumentTranslateInitializer) WithMemory(tm *TranslationMemory) *DocumentTranslateInitializer {
+	di.Translator.WithMemory(tm)
+	return di
+}
+
+// Suggest makes the translator suggest the segments of its memory similar to the lines not found in the memory.
+//
+// See TranslateInitializer.Suggest for more details.
+func (di *DocumentTranslateInitializer) Suggest(similarity float64) *DocumentTranslateInitializer {
+	di.Translator.Suggest(similarity)
+	return di
+}
+
+// ChunkSize sets the maximum number of characters translated at once to @size (a value between 1 and 5,000).
+func (di *DocumentTranslateInitializer) ChunkSize(size int) *DocumentTranslateInitializer {
+	if 1 <= size && size <= MaxLength {
+		di.Size = size
+	} else {
+		panic(ErrChunkSizeOutOfBound)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return di
+}
+
+// Concurrency sets the maximum number of concurrent requests to @workers (a value between 1 and 32).
+func (di *DocumentTranslateInitializer) Concurrency(workers int) *DocumentTranslateInitializer {
+	if 1 <= workers && workers <= 32 {
+		di.Workers = workers
+	} else {
+		panic(ErrWorkersOutOfBound)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return di
+}
+
+// RateLimit limits the requests to @n per second.
+func (di *DocumentTranslateInitializer) RateLimit(n int) *DocumentTranslateInitializer {
+	if 0 < n {
+		di.Interval = time.Second / time.Duration(n)
+	} else {
+		panic(ErrNonPositiveRate)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return di
+}
+
+// Collect returns the translation result, with the sentences of each line of the text.
+//
+// The blank lines of the text are kept as empty paragraphs.
+// When translating from Auto, the language is detected once from the beginning of the text.
+// With a translation memory, the lines found in the memory are not sent to the API,
+// and FromMemory reports whether the memory has some lines and serves all the lines with letters.
+func (di *DocumentTranslateInitializer) Collect() (res TranslateResult, err error) {
+	translator := di.Translator
+	if translator.SrcLang == Auto {
+		if translator.SrcLang, res.Detected, err = translator.detect(head(di.Text, MaxLength)); err != nil {
+			return
+		}
+	}
+	if translator.SrcLang == translator.TargetLang {
+		res.TranslatedText = untranslated(di.Text)
+		return
+	}
+
+	lines := splitLines(di.Text)
+	res.TranslatedText = make([][]string, len(lines))
+	for idx := range res.TranslatedText {
+		res.TranslatedText[idx] = []string{}
+	}
+
+	// the lines found in the memory are left out of the chunks
+	memory, pending, found := translator.Memory, lines, false
+	translator.Memory = nil
+	if memory != nil {
+		pending = append([]string{}, lines...)
+		for idx, line := range lines {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if translation, ok := memory.Lookup(translator.SrcLang, translator.TargetLang, line); ok {
+				res.TranslatedText[idx], pending[idx], found = flatten(untranslated(translation)), "", true
+			} else if 0 < translator.Similarity {
+				res.Suggestions = append(res.Suggestions,
+					memory.Suggest(translator.SrcLang, translator.TargetLang, line, translator.Similarity)...)
+			}
+		}
+	}
+
+	chunks := chunkLines(pending, di.Size)
+	res.FromMemory = found && len(chunks) == 0
+	results := make([][][]string, len(chunks))
+	hits := make([][]GlossaryHit, len(chunks))
+	errors := make([]error, len(chunks))
+
+	var (
+		ticker = time.NewTicker(di.Interval)
+		sem    = make(chan struct{}, di.Workers)
+		wg     sync.WaitGroup
+	)
+	defer ticker.Stop()
+
+	translate := func(text string) (TranslateResult, error) {
+		<-ticker.C
+		ti := translator
+		ti.Query = url.QueryEscape(text)
+		return ti.Collect()
+	}
+
+	for idx, c := range chunks {
+		wg.Add(1)
+		go func(idx int, c chunk) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			tr, err := translate(c.text)
+			if err != nil {
+				errors[idx] = err
+				return
+			}
+			paragraphs, glossary := tr.TranslatedText, tr.Glossary
+
+			// the paragraphs of a chunk are expected to match its lines,
+			// otherwise its lines are translated one by one
+			if !c.partial && len(paragraphs) != len(c.lines) {
+				paragraphs, glossary = nil, nil
+				for _, line := range c.lines {
+					tr, err := translate(strings.TrimSpace(lines[line]))
+					if err != nil {
+						errors[idx] = err
+						return
+					}
+					paragraphs = append(paragraphs, flatten(tr.TranslatedText))
+					glossary = mergeHits(glossary, tr.Glossary)
+				}
+			}
+			results[idx], hits[idx] = paragraphs, glossary
+		}(idx, c)
+	}
+	wg.Wait()
+
+	for _, e := range errors {
+		if e != nil {
+			return res, e
+		}
+	}
+
+	res.Glossary = mergeHits(hits...)
+	for idx, c := range chunks {
+		if c.partial {
+			line := c.lines[0]
+			res.TranslatedText[line] = append(res.TranslatedText[line], flatten(results[idx])...)
+			continue
+		}
+		for pos, line := range c.lines {
+			res.TranslatedText[line] = results[idx][pos]
+		}
+	}
+
+	if memory != nil {
+		for idx, line := range pending {
+			if strings.TrimSpace(line) != "" {
+				memory.Add(translator.SrcLang, translator.TargetLang, line, strings.Join(res.TranslatedText[idx], " "))
+			}
+		}
+	}
+	return
+}
+
+// flatten returns the sentences of @paragraphs.
+func flatten(paragraphs [][]string) (sentences []string) {
+	sentences = []string{}
+	for _, paragraph := range paragraphs {
+		sentences = append(sentences, paragraph...)
+	}
+	return
+}
+
+// head returns the first @n characters of @text, without the surrounding spaces.
+func head(text string, n int) string {
+	runes := []rune(strings.TrimSpace(text))
+	if n < len(runes) {
+		runes = runes[:n]
+	}
+	return strings.TrimSpace(string(runes))
+}
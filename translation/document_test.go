@@ -0,0 +1,82 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation_test
+
+import (
+	"internal/common"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/translation"
+)
+
+func TestSplitSentences(t *testing.T) {
+	text := `이 작품은 성장 소설과 유사하다.다만 그 대상이 어른이다. 원주율은 3.14이다! "정말?" 그렇다…`
+	expected := []string{
+		"이 작품은 성장 소설과 유사하다.",
+		"다만 그 대상이 어른이다.",
+		"원주율은 3.14이다!",
+		`"정말?"`,
+		"그렇다…",
+	}
+	if sentences := translation.SplitSentences(text); !reflect.DeepEqual(sentences, expected) {
+		t.Errorf("expected %q, got %q", expected, sentences)
+	}
+}
+
+func TestTranslateDocument(t *testing.T) {
+	paragraph := "이 대성당이라는 작품은 아주 짧은 시간 내에서의 한정된 공간의 사건을 다루고 있지만 작품의 의미에 대한 무게는 장편 소설 못지않게 강렬하다. "
+	text := strings.Repeat(paragraph, 40) + "\n\n" + strings.Repeat(paragraph, 3)
+
+	tr, err := translation.TranslateDocument(text).
+		From("kr").
+		To("en").
+		AuthorizeWith(common.REST_API_KEY).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tr.TranslatedText) != 3 || len(tr.TranslatedText[1]) != 0 {
+		t.Errorf("paragraphs are not preserved: %v", tr)
+	}
+	t.Log(tr.Text())
+}
+
+func TestAlign(t *testing.T) {
+	source := "안녕하세요. 반갑습니다.\n\n좋은 하루 되세요!"
+	tr := translation.TranslateResult{TranslatedText: [][]string{
+		{"Hello.", "Nice to meet you."},
+		{"Have a nice day!"},
+	}}
+
+	expected := translation.Alignment{
+		{Paragraph: 0, Source: "안녕하세요.", Target: "Hello."},
+		{Paragraph: 0, Source: "반갑습니다.", Target: "Nice to meet you."},
+		{Paragraph: 2, Source: "좋은 하루 되세요!", Target: "Have a nice day!"},
+	}
+	if a := translation.Align(source, tr); !reflect.DeepEqual(a, expected) {
+		t.Errorf("expected %v, got %v", expected, a)
+	}
+
+	tr.TranslatedText[0] = []string{"Hello, nice to meet you."}
+	if a := translation.Align(source, tr); len(a) != 2 || a[0].Source != "안녕하세요. 반갑습니다." {
+		t.Errorf("unexpected alignment: %v", a)
+	}
+
+	if paragraphs := tr.Paragraphs(); len(paragraphs) != 2 || len(tr.Sentences()) != 2 {
+		t.Errorf("unexpected paragraphs: %v", paragraphs)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import "errors"
+
+var (
+	ErrTooLongText          = errors.New("up to 5,000 characters are allowed")
+	ErrChunkSizeOutOfBound  = errors.New("chunk size must be between 1 and 5,000")
+	ErrWorkersOutOfBound    = errors.New("workers must be between 1 and 32")
+	ErrNonPositiveRate      = errors.New("rate must be positive")
+	ErrConfidenceOutOfBound = errors.New("confidence must be between 0 and 1")
+	ErrUndetectedLanguage   = errors.New("no language is detected with enough confidence")
+	ErrUnsupportedLanguage  = errors.New("unsupported language")
+	ErrInvalidSubtitle      = errors.New("invalid subtitle")
+	ErrSimilarityOutOfBound = errors.New("similarity must be greater than 0 and at most 1")
+	ErrInvalidResource      = errors.New("invalid resource")
+	ErrShareOutOfBound      = errors.New("share must be between 0.5 and 1")
+	ErrInvalidPivot         = errors.New("pivot language must be either en or kr")
+)
@@ -0,0 +1,261 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"internal/common"
+	"log"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// MultiTranslateResult represents the translations of a text into several languages.
+//
+// Pivoted maps each target language translated through another language to the latter.
+type MultiTranslateResult struct {
+	SrcLang  string                     `json:"src_lang"`
+	Detected *LanguageInfo              `json:"detected,omitempty"`
+	Results  map[string]TranslateResult `json:"results"`
+	Pivoted  map[string]string          `json:"pivoted,omitempty"`
+}
+
+// String implements fmt.Stringer.
+func (mr MultiTranslateResult) String() string { return common.String(mr) }
+
+// SaveAs saves mr to @filename.
+//
+// The file extension must be .json.
+func (mr MultiTranslateResult) SaveAs(filename string) error {
+	return common.SaveAsJSON(mr, filename)
+}
+
+// MultiTranslateInitializer is a lazy translator into several languages.
+type MultiTranslateInitializer struct {
+	Translator TranslateInitializer
+	Targets    []string
+	Pivot      string
+	Workers    int
+	Interval   time.Duration
+}
+
+// TranslateTo translates @text into each of @targets, or into all the Languages if none is given.
+//
+// The translations are requested concurrently, and the pairs of languages
+// which the API does not translate directly are translated through English.
+func TranslateTo(text string, targets ...string) *MultiTranslateInitializer {
+	mi := &MultiTranslateInitializer{
+		Translator: *Translate(text),
+		Pivot:      "en",
+		Workers:    4,
+		Interval:   100 * time.Millisecond,
+	}
+	if len(targets) == 0 {
+		targets = Languages
+	}
+
+	seen := map[string]bool{}
+	for _, target := range targets {
+		if !seen[target] {
+			seen[target] = true
+			// validates the target language
+			mi.Translator.To(target)
+			mi.Targets = append(mi.Targets, target)
+		}
+	}
+	mi.Translator.TargetLang = ""
+	return mi
+}
+
+// AuthorizeWith sets the authorization key to @key.
+func (mi *MultiTranslateInitializer) AuthorizeWith(key string) *MultiTranslateInitializer {
+	mi.Translator.AuthorizeWith(key)
+	return mi
+}
+
+// From sets the source language of the text to @src.
+//
+// See TranslateInitializer.From for the available languages.
+func (mi *MultiTranslateInitializer) From(src string) *MultiTranslateInitializer {
+	mi.Translator.From(src)
+	return mi
+}
+
+// MinConfidence sets the minimum confidence of the language detected from Auto to @confidence (a value between 0 and 1).
+func (mi *MultiTranslateInitializer) MinConfidence(confidence float64) *MultiTranslateInitializer {
+	mi.Translator.MinConfidence(confidence)
+	return mi
+}
+
+// WithGlossary makes the translator translate the terms of @g as it specifies.
+//
+// See TranslateInitializer.WithGlossary for more details.
+func (mi *MultiTranslateInitializer) WithGlossary(g *Glossary) *MultiTranslateInitializer {
+	mi.Translator.WithGlossary(g)
+	return mi
+}
+
+// WithMemory makes the translator reuse the translations in @tm and add the new ones to it.
+//
+// See TranslateInitializer.WithMemory for more details.
+func (mi *MultiTranslateInitializer) WithMemory(tm *TranslationMemory) *MultiTranslateInitializer {
+	mi.Translator.WithMemory(tm)
+	return mi
+}
+
+// PivotThrough sets the language which the unsupported pairs are translated through to @pivot.
+//
+// There are two available pivot languages:
+//
+// en: English (default)
+//
+// kr: Korean
+func (mi *MultiTranslateInitializer) PivotThrough(pivot string) *MultiTranslateInitializer {
+	switch pivot {
+	case "en", "kr":
+		mi.Pivot = pivot
+	default:
+		panic(ErrInvalidPivot)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return mi
+}
+
+// Concurrency sets the maximum number of concurrent requests to @workers (a value between 1 and 32).
+func (mi *MultiTranslateInitializer) Concurrency(workers int) *MultiTranslateInitializer {
+	if 1 <= workers && workers <= 32 {
+		mi.Workers = workers
+	} else {
+		panic(ErrWorkersOutOfBound)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return mi
+}
+
+// RateLimit limits the requests to @n per second.
+func (mi *MultiTranslateInitializer) RateLimit(n int) *MultiTranslateInitializer {
+	if 0 < n {
+		mi.Interval = time.Second / time.Duration(n)
+	} else {
+		panic(ErrNonPositiveRate)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return mi
+}
+
+// Collect returns the translations of the text by the target languages.
+//
+// The text is translated into the pivot language once for all the pivoted pairs.
+// If some of the translations fail, the first error is returned along with the others.
+func (mi *MultiTranslateInitializer) Collect() (res MultiTranslateResult, err error) {
+	translator := mi.Translator
+	if translator.SrcLang == Auto {
+		text, _ := url.QueryUnescape(translator.Query)
+		if translator.SrcLang, res.Detected, err = translator.detect(text); err != nil {
+			return
+		}
+	}
+	res.SrcLang = translator.SrcLang
+	res.Results = map[string]TranslateResult{}
+
+	var (
+		ticker = time.NewTicker(mi.Interval)
+		sem    = make(chan struct{}, mi.Workers)
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		errs   = make([]error, len(mi.Targets))
+	)
+	defer ticker.Stop()
+
+	translate := func(query, src, target string) (TranslateResult, error) {
+		sem <- struct{}{}
+		defer func() { <-sem }()
+
+		// only the requests to the API are rate-limited
+		local := src == target
+		if !local && translator.Memory != nil {
+			text, _ := url.QueryUnescape(query)
+			_, local = translator.Memory.Lookup(src, target, text)
+		}
+		if !local {
+			<-ticker.C
+		}
+
+		ti := translator
+		ti.Query, ti.SrcLang, ti.TargetLang = query, src, target
+		return ti.Collect()
+	}
+
+	// the text is translated into the pivot language first, if any pair needs it
+	var pivoted TranslateResult
+	for _, target := range mi.Targets {
+		if !direct(res.SrcLang, target) {
+			if pivoted, err = translate(translator.Query, res.SrcLang, mi.Pivot); err != nil {
+				return
+			}
+			res.Pivoted = map[string]string{}
+			break
+		}
+	}
+
+	for idx, target := range mi.Targets {
+		if target == mi.Pivot && res.Pivoted != nil {
+			res.Results[target] = pivoted
+			continue
+		}
+
+		wg.Add(1)
+		go func(idx int, target string) {
+			defer wg.Done()
+
+			query, src := translator.Query, res.SrcLang
+			if !direct(src, target) {
+				query, src = url.QueryEscape(pivoted.Text()), mi.Pivot
+			}
+			tr, err := translate(query, src, target)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[idx] = err
+				return
+			}
+			if src != res.SrcLang {
+				res.Pivoted[target] = src
+			}
+			res.Results[target] = tr
+		}(idx, target)
+	}
+	wg.Wait()
+
+	for _, e := range errs {
+		if e != nil {
+			return res, e
+		}
+	}
+	return
+}
+
+// direct reports whether the API translates @src into @target directly,
+// which is the case if either of them is Korean or English.
+func direct(src, target string) bool {
+	return src == target || src == "kr" || src == "en" || target == "kr" || target == "en"
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation_test
+
+import (
+	"internal/common"
+	"testing"
+	"time"
+
+	"github.com/maengsanha/kakao-developers-client/translation"
+)
+
+func TestTranslateToTargets(t *testing.T) {
+	if mi := translation.TranslateTo("Hello"); len(mi.Targets) != len(translation.Languages) {
+		t.Errorf("expected all the languages, got %v", mi.Targets)
+	}
+
+	tm := translation.NewTranslationMemory("")
+	tm.Add("en", "kr", "Hello", "안녕하세요")
+
+	// the translations served locally are not rate-limited
+	start := time.Now()
+	res, err := translation.TranslateTo("Hello", "en", "kr", "en").
+		From("en").
+		WithMemory(tm).
+		RateLimit(1).
+		Collect()
+	if elapsed := time.Since(start); time.Second/2 < elapsed {
+		t.Errorf("expected no wait, got %v", elapsed)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Results) != 2 || res.Results["en"].Text() != "Hello" || res.Results["kr"].Text() != "안녕하세요" {
+		t.Errorf("unexpected results: %v", res)
+	}
+	if len(res.Pivoted) != 0 {
+		t.Errorf("expected no pivoted pair, got %v", res.Pivoted)
+	}
+}
+
+func TestTranslateTo(t *testing.T) {
+	res, err := translation.TranslateTo("Bonjour tout le monde.", "kr", "en", "de", "jp").
+		From("fr").
+		AuthorizeWith(common.REST_API_KEY).
+		RateLimit(5).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Pivoted["de"] != "en" || res.Pivoted["jp"] != "en" || res.Pivoted["kr"] != "" {
+		t.Errorf("unexpected pivoted pairs: %v", res.Pivoted)
+	}
+	t.Log(res)
+}
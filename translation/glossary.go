@@ -0,0 +1,228 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"encoding/csv"
+	"internal/common"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// GlossaryEntry represents a term of a glossary.
+//
+// A term without its target is not translated at all.
+type GlossaryEntry struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// GlossaryHit represents the number of times a term of a glossary is found in a text.
+type GlossaryHit struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Count  int    `json:"count"`
+}
+
+// Glossary represents the terms translated in a fixed way between a pair of languages.
+//
+// A glossary without its languages applies to any pair.
+type Glossary struct {
+	SrcLang    string          `json:"src_lang"`
+	TargetLang string          `json:"target_lang"`
+	Entries    []GlossaryEntry `json:"entries"`
+}
+
+// NewGlossary returns an empty glossary from @src to @target.
+func NewGlossary(src, target string) *Glossary {
+	return &Glossary{SrcLang: src, TargetLang: target}
+}
+
+// LoadGlossary loads the glossary from @src to @target in @filename.
+//
+// @filename should end with .csv or .tsv, of which each row holds a term and optionally its target.
+// A header row of either "source, target" or the languages is skipped, and so are the lines starting with #.
+func LoadGlossary(filename, src, target string) (*Glossary, error) {
+	var comma rune
+	switch tokens := strings.Split(filename, "."); tokens[len(tokens)-1] {
+	case "csv":
+		comma = ','
+	case "tsv":
+		comma = '\t'
+	default:
+		return nil, common.ErrUnsupportedFormat
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comma = comma
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	g := NewGlossary(src, target)
+	for idx, row := range rows {
+		for len(row) < 2 {
+			row = append(row, "")
+		}
+		if idx == 0 && (strings.EqualFold(row[0], "source") && strings.EqualFold(row[1], "target") ||
+			strings.EqualFold(row[0], src) && strings.EqualFold(row[1], target)) {
+			continue
+		}
+		g.Add(row[0], row[1])
+	}
+	return g, nil
+}
+
+// Add adds @source translated into @target to g.
+func (g *Glossary) Add(source, target string) *Glossary {
+	if source = strings.TrimSpace(source); source != "" {
+		g.Entries = append(g.Entries, GlossaryEntry{Source: source, Target: strings.TrimSpace(target)})
+	}
+	return g
+}
+
+// Protect adds @terms not to be translated to g.
+func (g *Glossary) Protect(terms ...string) *Glossary {
+	for _, term := range terms {
+		g.Add(term, "")
+	}
+	return g
+}
+
+// SaveAs saves g to @filename.
+//
+// The file extension must be .json.
+func (g *Glossary) SaveAs(filename string) error { return common.SaveAsJSON(g, filename) }
+
+// applies reports whether g applies to the translation from @src to @target.
+func (g *Glossary) applies(src, target string) bool {
+	return (g.SrcLang == "" || g.SrcLang == src) && (g.TargetLang == "" || g.TargetLang == target)
+}
+
+// mask masks the terms of g in @text by the placeholders of @u standing for their targets,
+// and returns the hits.
+//
+// The placeholders already in @text are masked as well to be kept as they are.
+func (g *Glossary) mask(text string, u *unit) (string, []GlossaryHit) {
+	entries := append([]GlossaryEntry{}, g.Entries...)
+	sort.SliceStable(entries, func(i, j int) bool { return len(entries[i].Source) > len(entries[j].Source) })
+
+	var (
+		sb     strings.Builder
+		counts = map[string]int{}
+	)
+	for idx := 0; idx < len(text); {
+		if strings.HasPrefix(text[idx:], "⟦") {
+			if loc := placeholderPattern.FindStringIndex(text[idx:]); loc != nil && loc[0] == 0 {
+				sb.WriteString(u.mask(text[idx : idx+loc[1]]))
+				idx += loc[1]
+				continue
+			}
+		}
+		if entry, ok := matchEntry(entries, text, idx); ok {
+			replacement := entry.Target
+			if replacement == "" {
+				replacement = entry.Source
+			}
+			sb.WriteString(u.mask(replacement))
+			counts[entry.Source]++
+			idx += len(entry.Source)
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[idx:])
+		sb.WriteString(text[idx : idx+size])
+		idx += size
+	}
+
+	var hits []GlossaryHit
+	for _, entry := range g.Entries {
+		if count := counts[entry.Source]; 0 < count {
+			hits = append(hits, GlossaryHit{Source: entry.Source, Target: entry.Target, Count: count})
+			delete(counts, entry.Source)
+		}
+	}
+	return sb.String(), hits
+}
+
+// matchEntry returns the first of @entries found in @text at @idx.
+//
+// A Latin term matches only as a whole word.
+func matchEntry(entries []GlossaryEntry, text string, idx int) (GlossaryEntry, bool) {
+	for _, entry := range entries {
+		if !strings.HasPrefix(text[idx:], entry.Source) {
+			continue
+		}
+		end := idx + len(entry.Source)
+		if isASCIIWord(entry.Source[0]) && 0 < idx && isASCIIWord(text[idx-1]) {
+			continue
+		}
+		if isASCIIWord(entry.Source[len(entry.Source)-1]) && end < len(text) && isASCIIWord(text[end]) {
+			continue
+		}
+		return entry, true
+	}
+	return GlossaryEntry{}, false
+}
+
+func isASCIIWord(ch byte) bool {
+	return ('a' <= ch && ch <= 'z') || ('A' <= ch && ch <= 'Z') || ('0' <= ch && ch <= '9')
+}
+
+// restoreParagraphs restores the placeholders of @u in @paragraphs.
+//
+// The segments dropped by the translator are appended to the last sentence.
+func (u *unit) restoreParagraphs(paragraphs [][]string) [][]string {
+	used := make([]bool, len(u.masked))
+	var last *string
+	for _, sentences := range paragraphs {
+		for idx := range sentences {
+			sentences[idx] = u.replace(sentences[idx], used)
+			last = &sentences[idx]
+		}
+	}
+	if missing := u.missing(used); missing != "" && last != nil {
+		*last += missing
+	}
+	return paragraphs
+}
+
+// mergeHits adds up the counts of @hits by their terms.
+func mergeHits(hits ...[]GlossaryHit) (merged []GlossaryHit) {
+	index := map[string]int{}
+	for _, group := range hits {
+		for _, hit := range group {
+			if pos, ok := index[hit.Source]; ok {
+				merged[pos].Count += hit.Count
+				continue
+			}
+			index[hit.Source] = len(merged)
+			merged = append(merged, hit)
+		}
+	}
+	return
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation_test
+
+import (
+	"internal/common"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/translation"
+)
+
+func TestLoadGlossary(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "glossary_test.tsv")
+	if err := ioutil.WriteFile(filename, []byte("kr\ten\n# brands\n카카오톡\tKakaoTalk\n카카오\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := translation.LoadGlossary(filename, "kr", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []translation.GlossaryEntry{{Source: "카카오톡", Target: "KakaoTalk"}, {Source: "카카오"}}
+	if !reflect.DeepEqual(g.Entries, expected) {
+		t.Errorf("expected %v, got %v", expected, g.Entries)
+	}
+}
+
+func TestTranslateWithGlossary(t *testing.T) {
+	g := translation.NewGlossary("kr", "en").
+		Add("카카오톡", "KakaoTalk").
+		Protect("카카오")
+
+	tr, err := translation.Translate("카카오톡은 카카오의 메신저입니다.").
+		From("kr").
+		To("en").
+		WithGlossary(g).
+		AuthorizeWith(common.REST_API_KEY).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(tr.Text(), "KakaoTalk") || len(tr.Glossary) != 2 {
+		t.Errorf("the glossary is not applied: %v", tr)
+	}
+}
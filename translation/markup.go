@@ -0,0 +1,520 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	placeholderPattern = regexp.MustCompile(`⟦\s*(\d+)\s*⟧`)
+
+	// inlinePattern matches the segments of a text which must not be translated,
+	// such as URLs and format placeholders.
+	inlinePattern = strings.Join([]string{
+		`⟦\d+⟧`,
+		`<https?://[^>\s]+>`,
+		`</?[A-Za-z][^>]*>`,
+		`https?://[^\s<>()"']+`,
+		`\{\{[^{}]*\}\}`,
+		`\$?\{[\w.]+\}`,
+		`%(?:\d+\$)?[-+#0]*\d*(?:\.\d+)?[sdfv@]`,
+	}, "|")
+
+	textPattern = regexp.MustCompile(inlinePattern)
+
+	// markdownPattern additionally matches the Markdown code spans, images, links, emphases and entities.
+	markdownPattern = regexp.MustCompile(strings.Join([]string{
+		"``[^`]+``",
+		"`[^`]+`",
+		`!\[[^\]]*\]\([^)]*\)`,
+		`\[[^\]]*\]\([^)]*\)`,
+		`\[[^\]]*\]\[[^\]]*\]`,
+		`&(?:#\d+|#x[0-9A-Fa-f]+|\w+);`,
+		`[*_~]+`,
+		inlinePattern,
+	}, "|"))
+
+	markdownBlockPattern = regexp.MustCompile(`^[ \t]*(?:(?:>[ \t]?)+|#{1,6}[ \t]+|(?:[-*+]|\d+[.)])[ \t]+(?:\[[ xX]\][ \t]+)?)+`)
+	markdownRulePattern  = regexp.MustCompile(`^(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,}|=+)$`)
+	markdownRefPattern   = regexp.MustCompile(`^\[[^\]]+\]:\s*\S+`)
+	markdownTablePattern = regexp.MustCompile(`^\|?[\s:|-]+\|?$`)
+)
+
+// rawElements are the HTML elements whose content is kept as it is.
+var rawElements = map[string]bool{
+	"script": true, "style": true, "pre": true, "textarea": true,
+	"code": true, "kbd": true, "samp": true, "var": true,
+}
+
+// inlineElements are the HTML elements translated along with the surrounding text.
+var inlineElements = map[string]bool{
+	"a": true, "abbr": true, "b": true, "bdi": true, "bdo": true, "br": true, "cite": true,
+	"code": true, "data": true, "dfn": true, "em": true, "font": true, "i": true, "img": true,
+	"kbd": true, "mark": true, "q": true, "s": true, "samp": true, "small": true, "span": true,
+	"strong": true, "sub": true, "sup": true, "time": true, "u": true, "var": true, "wbr": true,
+}
+
+// unit is a piece of markup translated as a whole, with its protected segments masked by placeholders.
+type unit struct {
+	text   string
+	masked []string
+	escape func(string) string
+}
+
+// mask returns the placeholder standing for @s.
+func (u *unit) mask(s string) string {
+	u.masked = append(u.masked, s)
+	return "⟦" + strconv.Itoa(len(u.masked)-1) + "⟧"
+}
+
+// restore replaces the placeholders of @translated with the segments they stand for.
+//
+// The placeholders dropped by the translator are appended to the end, and the duplicated ones removed.
+func (u *unit) restore(translated string) string {
+	used := make([]bool, len(u.masked))
+	return u.replace(translated, used) + u.missing(used)
+}
+
+// replace replaces the placeholders of @translated not @used yet with the segments they stand for.
+func (u *unit) replace(translated string, used []bool) string {
+	return placeholderPattern.ReplaceAllStringFunc(translated, func(placeholder string) string {
+		n, _ := strconv.Atoi(placeholderPattern.FindStringSubmatch(placeholder)[1])
+		if len(u.masked) <= n || used[n] {
+			return ""
+		}
+		used[n] = true
+		return u.masked[n]
+	})
+}
+
+// missing returns the segments not @used.
+func (u *unit) missing(used []bool) (segments string) {
+	for n, ok := range used {
+		if !ok {
+			segments += u.masked[n]
+		}
+	}
+	return
+}
+
+// maskText masks the segments of @text which must not be translated.
+//
+// The masked segments are escaped, since they are restored into the markup as they are.
+func (u *unit) maskText(text string, markdown bool) string {
+	pattern := textPattern
+	if markdown {
+		pattern = markdownPattern
+	}
+
+	var (
+		sb   strings.Builder
+		last int
+	)
+	for _, loc := range pattern.FindAllStringIndex(text, -1) {
+		s := text[loc[0]:loc[1]]
+		sb.WriteString(text[last:loc[0]])
+		last = loc[1]
+
+		switch {
+		case markdown && strings.HasPrefix(s, "["):
+			// only the text of a link is translated
+			end := strings.IndexByte(s, ']')
+			sb.WriteString(u.mask("[") + u.maskText(s[1:end], true) + u.mask(s[end:]))
+		case markdown && strings.Trim(s, "*_~") == "" && isWordChar(text, loc[0]-1) && isWordChar(text, loc[1]):
+			// the underscores or asterisks within a word, as in snake_case, are not emphases
+			sb.WriteString(s)
+		default:
+			sb.WriteString(u.mask(u.escape(s)))
+		}
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// isWordChar reports whether the rune of @text around the byte offset @idx is a letter or a digit.
+func isWordChar(text string, idx int) bool {
+	var r rune
+	switch {
+	case idx < 0 || len(text) <= idx:
+		return false
+	case utf8.RuneStart(text[idx]):
+		r, _ = utf8.DecodeRuneInString(text[idx:])
+	default:
+		r, _ = utf8.DecodeLastRuneInString(text[:idx+1])
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// segment is either a literal kept as it is or a unit to translate.
+type segment struct {
+	literal string
+	unit    *unit
+}
+
+// markup represents an HTML or Markdown document split into segments.
+type markup []segment
+
+func (m *markup) literal(s string) {
+	if s != "" {
+		*m = append(*m, segment{literal: s})
+	}
+}
+
+// add adds @u with its masked @text, or @raw as it is if the text has nothing to translate.
+//
+// The whitespaces surrounding @raw are kept out of the translation.
+func (m *markup) add(raw string, u *unit, text string) {
+	if strings.IndexFunc(placeholderPattern.ReplaceAllString(text, ""), unicode.IsLetter) < 0 {
+		m.literal(raw)
+		return
+	}
+	trimmed := strings.TrimLeft(raw, " \t\r\n")
+	m.literal(raw[:len(raw)-len(trimmed)])
+	u.text = strings.Join(strings.Fields(text), " ")
+	*m = append(*m, segment{unit: u})
+	m.literal(trimmed[len(strings.TrimRight(trimmed, " \t\r\n")):])
+}
+
+func (m markup) units() (units []*unit) {
+	for _, seg := range m {
+		if seg.unit != nil {
+			units = append(units, seg.unit)
+		}
+	}
+	return
+}
+
+func (m markup) render(translations map[*unit]string) string {
+	var sb strings.Builder
+	for _, seg := range m {
+		if seg.unit == nil {
+			sb.WriteString(seg.literal)
+			continue
+		}
+		translated := translations[seg.unit]
+		if translated == "" {
+			translated = seg.unit.text
+		}
+		sb.WriteString(seg.unit.restore(seg.unit.escape(translated)))
+	}
+	return sb.String()
+}
+
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func escapeHTML(s string) string { return htmlEscaper.Replace(s) }
+
+func noEscape(s string) string { return s }
+
+// parseHTML splits @src into the literal tags and the text units between the block elements.
+func parseHTML(src string) (m markup) {
+	var (
+		raw  strings.Builder
+		text strings.Builder
+		cur  = &unit{escape: escapeHTML}
+	)
+	flush := func() {
+		m.add(raw.String(), cur, text.String())
+		raw.Reset()
+		text.Reset()
+		cur = &unit{escape: escapeHTML}
+	}
+
+	for idx := 0; idx < len(src); {
+		switch {
+		case strings.HasPrefix(src[idx:], "<!--"):
+			end := indexFrom(src, idx, "-->", 3)
+			flush()
+			m.literal(src[idx:end])
+			idx = end
+		case isTagStart(src, idx):
+			end := tagEnd(src, idx)
+			tag := src[idx:end]
+			name, closing := tagName(tag)
+			switch {
+			case rawElements[name] && !closing:
+				// the element is kept as a whole, up to its closing tag
+				end = indexFrom(strings.ToLower(src), end, "</"+name, 0)
+				end = tagEnd(src, end)
+				elem := src[idx:end]
+				if inlineElements[name] {
+					raw.WriteString(elem)
+					text.WriteString(cur.mask(elem))
+				} else {
+					flush()
+					m.literal(elem)
+				}
+			case inlineElements[name]:
+				raw.WriteString(tag)
+				text.WriteString(cur.mask(tag))
+			default:
+				flush()
+				m.literal(tag)
+			}
+			idx = end
+		default:
+			end := len(src)
+			if next := strings.IndexByte(src[idx+1:], '<'); 0 <= next {
+				end = idx + 1 + next
+			}
+			raw.WriteString(src[idx:end])
+			text.WriteString(cur.maskText(html.UnescapeString(src[idx:end]), false))
+			idx = end
+		}
+	}
+	flush()
+	return
+}
+
+// indexFrom returns the offset of @sep in @s from @from plus @skip, or the length of @s if there is none.
+func indexFrom(s string, from int, sep string, skip int) int {
+	if from >= len(s) {
+		return len(s)
+	}
+	if idx := strings.Index(s[from:], sep); 0 <= idx {
+		return from + idx + skip
+	}
+	return len(s)
+}
+
+func isTagStart(s string, idx int) bool {
+	if s[idx] != '<' || len(s) <= idx+1 {
+		return false
+	}
+	next := s[idx+1]
+	return next == '/' || next == '!' || ('a' <= next && next <= 'z') || ('A' <= next && next <= 'Z')
+}
+
+// tagEnd returns the offset right after the tag starting at @idx, skipping the quoted attributes.
+func tagEnd(s string, idx int) int {
+	var quote byte
+	for ; idx < len(s); idx++ {
+		switch ch := s[idx]; {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '>':
+			return idx + 1
+		}
+	}
+	return len(s)
+}
+
+func tagName(tag string) (name string, closing bool) {
+	tag = strings.TrimPrefix(tag, "<")
+	if strings.HasPrefix(tag, "/") {
+		tag, closing = tag[1:], true
+	}
+	end := strings.IndexFunc(tag, func(r rune) bool { return unicode.IsSpace(r) || r == '>' || r == '/' })
+	if end < 0 {
+		end = len(tag)
+	}
+	return strings.ToLower(tag[:end]), closing
+}
+
+// parseMarkdown splits @src into the literal Markdown syntax and the text units of its blocks.
+//
+// The lines of a paragraph are translated as a single line.
+func parseMarkdown(src string) (m markup) {
+	var paragraph []string
+	flush := func() {
+		if 0 < len(paragraph) {
+			u := &unit{escape: noEscape}
+			texts := make([]string, len(paragraph))
+			for idx, line := range paragraph {
+				texts[idx] = strings.TrimSpace(line)
+			}
+			m.add(strings.Join(paragraph, ""), u, u.maskText(strings.Join(texts, " "), true))
+			paragraph = nil
+		}
+	}
+
+	lines := strings.SplitAfter(src, "\n")
+	for idx := 0; idx < len(lines); idx++ {
+		line := lines[idx]
+		body := strings.TrimRight(line, "\r\n")
+		trimmed := strings.TrimSpace(body)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			flush()
+			end := idx + 1
+			for end < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[end]), trimmed[:3]) {
+				end++
+			}
+			if end < len(lines) {
+				end++
+			}
+			m.literal(strings.Join(lines[idx:end], ""))
+			idx = end - 1
+		case trimmed == "":
+			flush()
+			m.literal(line)
+		case len(paragraph) == 0 && (strings.HasPrefix(body, "    ") || strings.HasPrefix(body, "\t")):
+			m.literal(line)
+		case markdownRulePattern.MatchString(trimmed) || markdownRefPattern.MatchString(trimmed):
+			flush()
+			m.literal(line)
+		case strings.HasPrefix(trimmed, "|"):
+			flush()
+			if markdownTablePattern.MatchString(trimmed) {
+				m.literal(line)
+				continue
+			}
+			cells := strings.Split(body, "|")
+			for pos, cell := range cells {
+				if 0 < pos {
+					m.literal("|")
+				}
+				u := &unit{escape: noEscape}
+				m.add(cell, u, u.maskText(cell, true))
+			}
+			m.literal(line[len(body):])
+		case markdownBlockPattern.MatchString(body):
+			flush()
+			prefix := markdownBlockPattern.FindString(body)
+			m.literal(prefix)
+			u := &unit{escape: noEscape}
+			m.add(line[len(prefix):], u, u.maskText(body[len(prefix):], true))
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+	return
+}
+
+// MarkupTranslateInitializer is a lazy translator of HTML or Markdown documents.
+type MarkupTranslateInitializer struct {
+	Source   string
+	Format   string
+	Document *DocumentTranslateInitializer
+}
+
+// TranslateHTML translates the text of the HTML document @src.
+//
+// The tags, attributes, comments and the content of the elements such as script, style, pre and code
+// are kept as they are, and so are the URLs and format placeholders in the text.
+// The text between the block elements is translated as a whole, along with its inline elements.
+func TranslateHTML(src string) *MarkupTranslateInitializer {
+	return &MarkupTranslateInitializer{
+		Source:   src,
+		Format:   "html",
+		Document: TranslateDocument(""),
+	}
+}
+
+// TranslateMarkdown translates the text of the Markdown document @src.
+//
+// The code blocks, code spans, images, link destinations, inline HTML,
+// URLs and format placeholders are kept as they are.
+// Each paragraph, heading, list item and table cell is translated as a whole, along with its emphases.
+func TranslateMarkdown(src string) *MarkupTranslateInitializer {
+	return &MarkupTranslateInitializer{
+		Source:   src,
+		Format:   "markdown",
+		Document: TranslateDocument(""),
+	}
+}
+
+// AuthorizeWith sets the authorization key to @key.
+func (mi *MarkupTranslateInitializer) AuthorizeWith(key string) *MarkupTranslateInitializer {
+	mi.Document.AuthorizeWith(key)
+	return mi
+}
+
+// From sets the source language of the document to @src.
+//
+// See TranslateInitializer.From for the available languages.
+func (mi *MarkupTranslateInitializer) From(src string) *MarkupTranslateInitializer {
+	mi.Document.From(src)
+	return mi
+}
+
+// To sets the target language of the document to @target.
+//
+// See TranslateInitializer.To for the available languages.
+func (mi *MarkupTranslateInitializer) To(target string) *MarkupTranslateInitializer {
+	mi.Document.To(target)
+	return mi
+}
+
+// WithGlossary makes the translator translate the terms of @g as it specifies.
+//
+// See TranslateInitializer.WithGlossary for more details.
+func (mi *MarkupTranslateInitializer) WithGlossary(g *Glossary) *MarkupTranslateInitializer {
+	mi.Document.WithGlossary(g)
+	return mi
+}
+
+// WithMemory makes the translator reuse the translations in @tm and add the new ones to it.
+//
+// See DocumentTranslateInitializer.WithMemory for more details.
+func (mi *MarkupTranslateInitializer) WithMemory(tm *TranslationMemory) *MarkupTranslateInitializer {
+	mi.Document.WithMemory(tm)
+	return mi
+}
+
+// Concurrency sets the maximum number of concurrent requests to @workers (a value between 1 and 32).
+func (mi *MarkupTranslateInitializer) Concurrency(workers int) *MarkupTranslateInitializer {
+	mi.Document.Concurrency(workers)
+	return mi
+}
+
+// RateLimit limits the requests to @n per second.
+func (mi *MarkupTranslateInitializer) RateLimit(n int) *MarkupTranslateInitializer {
+	mi.Document.RateLimit(n)
+	return mi
+}
+
+// Collect returns the translated document.
+func (mi *MarkupTranslateInitializer) Collect() (string, error) {
+	var m markup
+	if mi.Format == "html" {
+		m = parseHTML(mi.Source)
+	} else {
+		m = parseMarkdown(mi.Source)
+	}
+
+	units := m.units()
+	texts := make([]string, len(units))
+	for idx, u := range units {
+		texts[idx] = u.text
+	}
+
+	doc := *mi.Document
+	doc.Text = strings.Join(texts, "\n")
+	tr, err := doc.Collect()
+	if err != nil {
+		return "", err
+	}
+
+	translations := map[*unit]string{}
+	for idx, paragraph := range tr.Paragraphs() {
+		if idx < len(units) {
+			translations[units[idx]] = paragraph
+		}
+	}
+	return m.render(translations), nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation_test
+
+import (
+	"internal/common"
+	"strings"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/translation"
+)
+
+const page = `<html><head><style>p { color: red; }</style><title>My page</title></head>
+<body>
+  <h1 class="title">Hello &amp; welcome</h1>
+  <p>Visit <a href="https://example.com" title="keep me">our site</a>. Use <code>go build</code> to build {name}.</p>
+  <pre>keep
+  this</pre>
+  <!-- comment -->
+  <p>a &lt; b</p>
+</body></html>`
+
+const readme = "# Title\n\nSome **bold text. Across sentences** and `code` with [a link](http://example.com) and snake_case.\n\n" +
+	"```go\nfmt.Println(\"x\")\n```\n\n- item one\n- [ ] task two %s\n\n| col a | col b |\n|---|---|\n| cell | 1 |\n"
+
+func TestTranslateMarkupUnchanged(t *testing.T) {
+	// translating into the source language keeps the documents as they are
+	if out, err := translation.TranslateHTML(page).From("en").To("en").Collect(); err != nil || out != page {
+		t.Errorf("expected %s, got %s (%v)", page, out, err)
+	}
+	if out, err := translation.TranslateMarkdown(readme).From("en").To("en").Collect(); err != nil || out != readme {
+		t.Errorf("expected %s, got %s (%v)", readme, out, err)
+	}
+}
+
+func TestTranslateMarkupMasks(t *testing.T) {
+	// the memory holds the translations of the masked texts, with the placeholders moved around
+	tm := translation.NewTranslationMemory("")
+	for source, target := range map[string]string{
+		"My page":         "내 페이지",
+		"Hello & welcome": "안녕 & 환영",
+		"Visit ⟦0⟧our site⟦1⟧. Use ⟦2⟧ to build ⟦3⟧.": "⟦0⟧우리 사이트⟦1⟧를 방문하세요. ⟦3⟧를 빌드하려면 ⟦2⟧를 쓰세요.",
+		"a < b": "a < b",
+		"Title": "제목",
+		"Some ⟦0⟧bold text. Across sentences⟦1⟧ and ⟦2⟧ with ⟦3⟧a link⟦4⟧ and snake_case.": "⟦3⟧링크⟦4⟧와 ⟦2⟧, snake_case와 함께 ⟦0⟧굵은 글씨⟦1⟧.",
+		"item one":     "항목 하나",
+		"task two ⟦0⟧": "작업 둘 ⟦0⟧",
+		"col a":        "열 가",
+		"col b":        "열 나",
+		"cell":         "칸",
+	} {
+		tm.Add("en", "kr", source, target)
+	}
+
+	out, err := translation.TranslateHTML(page).From("en").To("kr").WithMemory(tm).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		`<h1 class="title">안녕 &amp; 환영</h1>`,
+		`<a href="https://example.com" title="keep me">우리 사이트</a>를 방문하세요.`,
+		`{name}를 빌드하려면 <code>go build</code>를 쓰세요.`,
+		"<pre>keep\n  this</pre>",
+		"<style>p { color: red; }</style>",
+		"<!-- comment -->",
+		"<p>a &lt; b</p>",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %s in %s", s, out)
+		}
+	}
+
+	out, err = translation.TranslateMarkdown(readme).From("en").To("kr").WithMemory(tm).Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		"# 제목\n",
+		"[링크](http://example.com)와 `code`, snake_case와 함께 **굵은 글씨**.",
+		"```go\nfmt.Println(\"x\")\n```",
+		"- [ ] 작업 둘 %s\n",
+		"| 열 가 | 열 나 |\n|---|---|\n| 칸 | 1 |",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %s in %s", s, out)
+		}
+	}
+}
+
+func TestTranslateHTML(t *testing.T) {
+	out, err := translation.TranslateHTML(page).
+		From("en").
+		To("kr").
+		AuthorizeWith(common.REST_API_KEY).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(out)
+}
+
+func TestTranslateMarkdown(t *testing.T) {
+	out, err := translation.TranslateMarkdown(readme).
+		From("en").
+		To("kr").
+		AuthorizeWith(common.REST_API_KEY).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(out)
+}
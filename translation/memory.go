@@ -0,0 +1,313 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"encoding/xml"
+	"internal/common"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryEntry represents a segment and its translation in a translation memory.
+type MemoryEntry struct {
+	SrcLang    string    `json:"src_lang"`
+	TargetLang string    `json:"target_lang"`
+	Source     string    `json:"source"`
+	Target     string    `json:"target"`
+	Updated    time.Time `json:"updated"`
+}
+
+// FuzzyMatch represents an entry of a translation memory similar to a segment.
+//
+// Similarity is between 0 and 1, based on the edit distance between the segments.
+type FuzzyMatch struct {
+	MemoryEntry
+	Similarity float64 `json:"similarity"`
+}
+
+// TranslationMemory is a store of translated segments, safe for concurrent use.
+//
+// The segments are looked up by their languages and their text with the spaces normalized.
+type TranslationMemory struct {
+	Filename string
+	mu       sync.RWMutex
+	entries  map[string]MemoryEntry
+}
+
+// NewTranslationMemory returns an empty translation memory saved to @filename.
+//
+// @filename should end with .json.
+func NewTranslationMemory(filename string) *TranslationMemory {
+	return &TranslationMemory{Filename: filename, entries: map[string]MemoryEntry{}}
+}
+
+// LoadTranslationMemory loads the translation memory saved to @filename.
+//
+// A translation memory which is not saved yet is empty.
+func LoadTranslationMemory(filename string) (*TranslationMemory, error) {
+	tm := NewTranslationMemory(filename)
+
+	var entries []MemoryEntry
+	if err := common.LoadJSON(&entries, filename); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		tm.entries[memoryKey(entry.SrcLang, entry.TargetLang, entry.Source)] = entry
+	}
+	return tm, nil
+}
+
+// Save saves tm to its file.
+func (tm *TranslationMemory) Save() error { return tm.SaveAs(tm.Filename) }
+
+// SaveAs saves tm to @filename.
+//
+// The file extension must be .json.
+func (tm *TranslationMemory) SaveAs(filename string) error {
+	return common.SaveAsJSON(tm.Entries(), filename)
+}
+
+// Entries returns the entries of tm, sorted by their languages and segments.
+func (tm *TranslationMemory) Entries() []MemoryEntry {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	entries := make([]MemoryEntry, 0, len(tm.entries))
+	for _, entry := range tm.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return memoryKey(entries[i].SrcLang, entries[i].TargetLang, entries[i].Source) <
+			memoryKey(entries[j].SrcLang, entries[j].TargetLang, entries[j].Source)
+	})
+	return entries
+}
+
+// Len returns the number of entries of tm.
+func (tm *TranslationMemory) Len() int {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	return len(tm.entries)
+}
+
+// Add adds @source translated from @src into @target as @translation to tm.
+func (tm *TranslationMemory) Add(src, target, source, translation string) {
+	source, translation = normalizeSegment(source), strings.TrimSpace(translation)
+	if source == "" || translation == "" {
+		return
+	}
+
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.entries[memoryKey(src, target, source)] = MemoryEntry{
+		SrcLang:    src,
+		TargetLang: target,
+		Source:     source,
+		Target:     translation,
+		Updated:    time.Now(),
+	}
+}
+
+// Lookup returns the translation of @source from @src into @target in tm.
+func (tm *TranslationMemory) Lookup(src, target, source string) (string, bool) {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+	entry, ok := tm.entries[memoryKey(src, target, normalizeSegment(source))]
+	return entry.Target, ok
+}
+
+// Suggest returns the entries of tm from @src into @target similar to @source at least by @similarity,
+// from the most similar one.
+func (tm *TranslationMemory) Suggest(src, target, source string, similarity float64) (matches []FuzzyMatch) {
+	segment := []rune(normalizeSegment(source))
+
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	for _, entry := range tm.entries {
+		if entry.SrcLang != src || entry.TargetLang != target {
+			continue
+		}
+		candidate := []rune(entry.Source)
+
+		// the similarity can not exceed the ratio of the lengths
+		shorter, longer := len(segment), len(candidate)
+		if longer < shorter {
+			shorter, longer = longer, shorter
+		}
+		if longer == 0 || float64(shorter)/float64(longer) < similarity {
+			continue
+		}
+
+		if score := 1 - float64(levenshtein(segment, candidate))/float64(longer); similarity <= score {
+			matches = append(matches, FuzzyMatch{MemoryEntry: entry, Similarity: score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Similarity != matches[j].Similarity {
+			return matches[i].Similarity > matches[j].Similarity
+		}
+		return matches[i].Source < matches[j].Source
+	})
+	return
+}
+
+func memoryKey(src, target, source string) string { return src + "\x00" + target + "\x00" + source }
+
+// normalizeSegment trims @segment and collapses its spaces.
+func normalizeSegment(segment string) string { return strings.Join(strings.Fields(segment), " ") }
+
+// levenshtein returns the edit distance between @lhs and @rhs.
+func levenshtein(lhs, rhs []rune) int {
+	prev := make([]int, len(rhs)+1)
+	cur := make([]int, len(rhs)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(lhs); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rhs); j++ {
+			cost := 1
+			if lhs[i-1] == rhs[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rhs)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+type tmx struct {
+	XMLName xml.Name  `xml:"tmx"`
+	Version string    `xml:"version,attr"`
+	Header  tmxHeader `xml:"header"`
+	Units   []tmxUnit `xml:"body>tu"`
+}
+
+type tmxHeader struct {
+	CreationTool        string `xml:"creationtool,attr"`
+	CreationToolVersion string `xml:"creationtoolversion,attr"`
+	SegType             string `xml:"segtype,attr"`
+	TMF                 string `xml:"o-tmf,attr"`
+	AdminLang           string `xml:"adminlang,attr"`
+	SrcLang             string `xml:"srclang,attr"`
+	DataType            string `xml:"datatype,attr"`
+}
+
+type tmxUnit struct {
+	Variants []tmxVariant `xml:"tuv"`
+}
+
+type tmxVariant struct {
+	Lang    string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	OldLang string `xml:"lang,attr,omitempty"`
+	Segment string `xml:"seg"`
+}
+
+// tmxLanguages maps the translation language codes to the ISO 639-1 codes of TMX.
+var tmxLanguages = map[string]string{"kr": "ko", "jp": "ja", "cn": "zh"}
+
+// ExportTMX exports the entries of tm to @filename in the TMX 1.4 format.
+func (tm *TranslationMemory) ExportTMX(filename string) error {
+	doc := tmx{
+		Version: "1.4",
+		Header: tmxHeader{
+			CreationTool:        "kakao-developers-client",
+			CreationToolVersion: "1.0",
+			SegType:             "sentence",
+			TMF:                 "kakao-developers-client",
+			AdminLang:           "en",
+			SrcLang:             "*all*",
+			DataType:            "plaintext",
+		},
+	}
+	for _, entry := range tm.Entries() {
+		doc.Units = append(doc.Units, tmxUnit{Variants: []tmxVariant{
+			{Lang: tmxLanguage(entry.SrcLang), Segment: entry.Source},
+			{Lang: tmxLanguage(entry.TargetLang), Segment: entry.Target},
+		}})
+	}
+
+	bs, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, append([]byte(xml.Header), bs...), 0o644)
+}
+
+// ImportTMX imports the translation units in @filename in the TMX format to tm.
+//
+// Each unit is imported for every pair of its languages supported by the Translation API,
+// and the other languages are skipped.
+func (tm *TranslationMemory) ImportTMX(filename string) error {
+	bs, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	var doc tmx
+	if err := xml.Unmarshal(bs, &doc); err != nil {
+		return err
+	}
+
+	for _, tu := range doc.Units {
+		for _, src := range tu.Variants {
+			for _, target := range tu.Variants {
+				srcLang, ok := src.language()
+				if !ok {
+					continue
+				}
+				targetLang, ok := target.language()
+				if !ok || srcLang == targetLang {
+					continue
+				}
+				tm.Add(srcLang, targetLang, src.Segment, target.Segment)
+			}
+		}
+	}
+	return nil
+}
+
+func tmxLanguage(code string) string {
+	if lang, ok := tmxLanguages[code]; ok {
+		return lang
+	}
+	return code
+}
+
+// language returns the translation language code of tv.
+func (tv tmxVariant) language() (string, bool) {
+	lang := tv.Lang
+	if lang == "" {
+		lang = tv.OldLang
+	}
+	return TranslateCode(strings.SplitN(lang, "-", 2)[0])
+}
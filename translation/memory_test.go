@@ -0,0 +1,123 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation_test
+
+import (
+	"internal/common"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/translation"
+)
+
+func TestTranslationMemory(t *testing.T) {
+	dir := t.TempDir()
+	tm := translation.NewTranslationMemory(filepath.Join(dir, "memory.json"))
+	tm.Add("kr", "en", "  저장 되었습니다. ", "It has been saved.")
+	tm.Add("kr", "en", "삭제 되었습니다.", "It has been deleted.")
+	tm.Add("kr", "jp", "저장 되었습니다.", "保存されました。")
+
+	if translation, ok := tm.Lookup("kr", "en", "저장\t되었습니다."); !ok || translation != "It has been saved." {
+		t.Errorf("expected an exact hit, got %q", translation)
+	}
+	if _, ok := tm.Lookup("en", "kr", "저장 되었습니다."); ok {
+		t.Error("expected no hit for the reversed languages")
+	}
+
+	matches := tm.Suggest("kr", "en", "저장 되었어요.", 0.6)
+	if len(matches) != 1 || matches[0].Target != "It has been saved." || 1 <= matches[0].Similarity {
+		t.Errorf("unexpected suggestions: %+v", matches)
+	}
+
+	if err := tm.Save(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := translation.LoadTranslationMemory(tm.Filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Len() != 3 {
+		t.Errorf("expected 3 entries, got %d", loaded.Len())
+	}
+	if empty, err := translation.LoadTranslationMemory(filepath.Join(dir, "empty.json")); err != nil || empty.Len() != 0 {
+		t.Errorf("expected an empty memory, got %v", err)
+	}
+
+	filename := filepath.Join(dir, "memory.tmx")
+	if err := tm.ExportTMX(filename); err != nil {
+		t.Fatal(err)
+	}
+	bs, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(bs), `xml:lang="ko"`) || !strings.Contains(string(bs), `xml:lang="ja"`) {
+		t.Errorf("expected ISO language codes, got %s", bs)
+	}
+
+	imported := translation.NewTranslationMemory("")
+	if err := imported.ImportTMX(filename); err != nil {
+		t.Fatal(err)
+	}
+	if translation, ok := imported.Lookup("jp", "kr", "保存されました。"); !ok || translation != "저장 되었습니다." {
+		t.Errorf("expected the reversed pair to be imported, got %q", translation)
+	}
+}
+
+func TestTranslateWithMemory(t *testing.T) {
+	tm := translation.NewTranslationMemory("")
+	tm.Add("kr", "en", "저장 되었습니다.", "It has been saved.")
+	tm.Add("kr", "en", "삭제 되었습니다.", "It has been deleted.")
+
+	tr, err := translation.Translate("저장 되었습니다.").
+		From("kr").
+		To("en").
+		AuthorizeWith(common.REST_API_KEY).
+		WithMemory(tm).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tr.FromMemory || tr.Text() != "It has been saved." {
+		t.Errorf("expected a translation from the memory, got %v", tr)
+	}
+
+	tr, err = translation.TranslateDocument("저장 되었습니다.\n\n삭제 되었습니다.").
+		From("kr").
+		To("en").
+		AuthorizeWith(common.REST_API_KEY).
+		WithMemory(tm).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tr.FromMemory || tr.Text() != "It has been saved.\n\nIt has been deleted." {
+		t.Errorf("expected a translation from the memory, got %v", tr)
+	}
+
+	tr, err = translation.TranslateDocument("\n  \n").
+		From("kr").
+		To("en").
+		WithMemory(tm).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.FromMemory {
+		t.Errorf("expected no translation from the memory, got %v", tr)
+	}
+}
@@ -16,3 +16,6 @@
 package translation
 
 const prefix = "https://dapi.kakao.com/"
+
+// MaxLength is the maximum number of characters translated or detected at once.
+const MaxLength = 5000
@@ -0,0 +1,1048 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"bytes"
+	"internal/common"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/goccy/go-json"
+)
+
+var (
+	// resourcePattern matches the segments of a localized string which must not be translated,
+	// such as CDATA delimiters, line breaks, escape sequences and the printf, Python and ICU style placeholders.
+	resourcePattern = regexp.MustCompile(strings.Join([]string{
+		`<!\[CDATA\[|\]\]>`,
+		`\n`,
+		`\\(?:[uU][0-9A-Fa-f]{4}|.)`,
+		`&(?:#\d+|#x[0-9A-Fa-f]+|\w+);`,
+		`%%`,
+		`%\{\w+\}`,
+		`%\(\w+\)[-+#0]*\d*(?:\.\d+)?[sdifeEgGxXor]`,
+		`%(?:\d+\$)?[-+#0]*\d*(?:\.\d+)?(?:hh|h|ll|l|q|z|t|j)?[sdiufFeEgGxXoOcCpaA@]`,
+		inlinePattern,
+	}, "|"))
+
+	androidNamePattern         = regexp.MustCompile(`\bname\s*=\s*"([^"]*)"`)
+	androidUntranslatedPattern = regexp.MustCompile(`\btranslatable\s*=\s*"false"`)
+	yamlPlainKeyPattern        = regexp.MustCompile(`^[A-Za-z0-9_][\w.-]*$`)
+	yamlScalarPattern          = regexp.MustCompile(`^(?:~|null|Null|NULL|true|True|TRUE|false|False|FALSE|yes|Yes|no|No|on|On|off|Off|[-+]?(?:\d[\d_]*)?\.?\d+(?:[eE][-+]?\d+)?|0x[0-9A-Fa-f]+|\.inf|\.nan)$`)
+)
+
+// ResourceEntry represents a localized string of a resource.
+//
+// Path holds the nested keys of a JSON or YAML entry, of which Key is the keys joined with dots.
+// For PO, Key is the msgid and Context the msgctxt, and for Android the element name of a raw entry.
+//
+// Raw holds an entry kept as it is rather than translated, such as a JSON number, a YAML list,
+// a PO plural entry or an Android string array, and Attrs the attributes of an Android string but its name.
+type ResourceEntry struct {
+	Key     string   `json:"key"`
+	Path    []string `json:"path,omitempty"`
+	Context string   `json:"context,omitempty"`
+	Value   string   `json:"value"`
+	Comment string   `json:"comment,omitempty"`
+	Attrs   string   `json:"attrs,omitempty"`
+	Raw     string   `json:"raw,omitempty"`
+}
+
+// id returns the identifier of e, which matches the same entry of another resource in any format.
+func (e ResourceEntry) id() string { return e.Context + "\x04" + e.Key }
+
+// path returns the nested keys of e.
+func (e ResourceEntry) path() []string {
+	if 0 < len(e.Path) {
+		return e.Path
+	}
+	return []string{e.Key}
+}
+
+// Resource represents a localization resource in one of the following formats:
+//
+// json: nested JSON i18n file
+//
+// yaml: nested YAML i18n file, without anchors and multiple documents
+//
+// po: gettext PO file
+//
+// xml: Android strings.xml
+//
+// strings: iOS .strings file
+//
+// Header holds the prologue of an Android resource up to its resources element,
+// and Footer the comments after the last entry.
+type Resource struct {
+	Format  string          `json:"format"`
+	Header  string          `json:"header,omitempty"`
+	Entries []ResourceEntry `json:"entries"`
+	Footer  string          `json:"footer,omitempty"`
+}
+
+// ParseResource parses @text in @format.
+//
+// See Resource for the available formats.
+func ParseResource(text, format string) (r Resource, err error) {
+	text = strings.TrimPrefix(strings.ReplaceAll(text, "\r\n", "\n"), "\ufeff")
+
+	switch format = resourceFormat(format); format {
+	case "json":
+		r, err = parseJSONResource(text)
+	case "yaml":
+		r, err = parseYAMLResource(text)
+	case "po":
+		r, err = parsePOResource(text)
+	case "xml":
+		r, err = parseAndroidResource(text)
+	case "strings":
+		r, err = parseStringsResource(text)
+	default:
+		return r, common.ErrUnsupportedFormat
+	}
+	r.Format = format
+	return
+}
+
+// LoadResource loads the resource in @filename.
+//
+// @filename should end with .json, .yaml, .yml, .po, .pot, .xml or .strings.
+func LoadResource(filename string) (r Resource, err error) {
+	tokens := strings.Split(filename, ".")
+	if resourceFormat(tokens[len(tokens)-1]) == "" {
+		return r, common.ErrUnsupportedFormat
+	}
+	bs, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return
+	}
+	return ParseResource(string(bs), tokens[len(tokens)-1])
+}
+
+// String renders r in its format.
+func (r Resource) String() string {
+	switch r.Format {
+	case "json":
+		return r.renderJSON()
+	case "yaml":
+		return r.renderYAML()
+	case "po":
+		return r.renderPO()
+	case "xml":
+		return r.renderAndroid()
+	case "strings":
+		return r.renderStrings()
+	}
+	return ""
+}
+
+// SaveAs saves r to @filename.
+//
+// @filename should end with .json, .yaml, .yml, .po, .pot, .xml or .strings, which decides the format of the file.
+func (r Resource) SaveAs(filename string) error {
+	tokens := strings.Split(filename, ".")
+	if r.Format = resourceFormat(tokens[len(tokens)-1]); r.Format == "" {
+		return common.ErrUnsupportedFormat
+	}
+	return ioutil.WriteFile(filename, []byte(r.String()), 0o644)
+}
+
+// resourceFormat returns the format of the file extension @ext.
+func resourceFormat(ext string) string {
+	switch ext = strings.ToLower(ext); ext {
+	case "json", "po", "xml", "strings", "yaml":
+		return ext
+	case "yml":
+		return "yaml"
+	case "pot":
+		return "po"
+	}
+	return ""
+}
+
+func parseJSONResource(text string) (r Resource, err error) {
+	var walk func(raw json.RawMessage, path []string) error
+	walk = func(raw json.RawMessage, path []string) error {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, ok := tok.(string)
+			if !ok {
+				return ErrInvalidResource
+			}
+
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return err
+			}
+			value = bytes.TrimSpace(value)
+			keys := append(append([]string{}, path...), key)
+			entry := ResourceEntry{Key: strings.Join(keys, "."), Path: keys}
+
+			switch {
+			case value[0] == '{' && !bytes.Equal(bytes.Join(bytes.Fields(value), nil), []byte("{}")):
+				if err := walk(value, keys); err != nil {
+					return err
+				}
+				continue
+			case value[0] == '"':
+				if err := json.Unmarshal(value, &entry.Value); err != nil {
+					return err
+				}
+			default:
+				entry.Raw = string(value)
+			}
+			r.Entries = append(r.Entries, entry)
+		}
+		return nil
+	}
+
+	if text = strings.TrimSpace(text); !strings.HasPrefix(text, "{") {
+		return r, ErrInvalidResource
+	}
+	err = walk(json.RawMessage(text), nil)
+	return
+}
+
+// resourceNode is a node of the tree of the nested keys of a resource.
+type resourceNode struct {
+	key      string
+	entry    *ResourceEntry
+	children []*resourceNode
+	index    map[string]*resourceNode
+}
+
+// resourceTree returns the tree of @entries, in which the keys are ordered as they first appear.
+func resourceTree(entries []ResourceEntry) *resourceNode {
+	root := &resourceNode{index: map[string]*resourceNode{}}
+	for idx := range entries {
+		node := root
+		for _, key := range entries[idx].path() {
+			child, ok := node.index[key]
+			if !ok || child.entry != nil {
+				child = &resourceNode{key: key, index: map[string]*resourceNode{}}
+				node.index[key] = child
+				node.children = append(node.children, child)
+			}
+			node = child
+		}
+		node.entry = &entries[idx]
+	}
+	return root
+}
+
+func (r Resource) renderJSON() string {
+	var (
+		sb     strings.Builder
+		render func(node *resourceNode, indent string)
+	)
+	render = func(node *resourceNode, indent string) {
+		sb.WriteString("{")
+		for idx, child := range node.children {
+			if 0 < idx {
+				sb.WriteString(",")
+			}
+			sb.WriteString("\n" + indent + "  " + jsonString(child.key) + ": ")
+			switch {
+			case child.entry == nil:
+				render(child, indent+"  ")
+			case child.entry.Raw != "":
+				var buf bytes.Buffer
+				if err := json.Indent(&buf, []byte(child.entry.Raw), indent+"  ", "  "); err != nil {
+					sb.WriteString(child.entry.Raw)
+				} else {
+					sb.Write(buf.Bytes())
+				}
+			default:
+				sb.WriteString(jsonString(child.entry.Value))
+			}
+		}
+		if 0 < len(node.children) {
+			sb.WriteString("\n" + indent)
+		}
+		sb.WriteString("}")
+	}
+	render(resourceTree(r.Entries), "")
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// jsonString returns @s quoted as a JSON string, without escaping the HTML characters.
+func jsonString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimRight(buf.String(), "\n")
+}
+
+func parseYAMLResource(text string) (r Resource, err error) {
+	type level struct {
+		indent int
+		key    string
+	}
+
+	var (
+		lines    = strings.Split(text, "\n")
+		stack    []level
+		comments []string
+	)
+	indentOf := func(line string) int { return len(line) - len(strings.TrimLeft(line, " ")) }
+
+	for idx := 0; idx < len(lines); idx++ {
+		line := strings.TrimRight(lines[idx], " \t")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "" || trimmed == "---":
+			continue
+		case strings.HasPrefix(trimmed, "#"):
+			comments = append(comments, strings.TrimSpace(strings.TrimPrefix(trimmed, "#")))
+			continue
+		}
+
+		indent := indentOf(line)
+		key, rest, ok := splitYAMLKey(trimmed)
+		if !ok {
+			return r, ErrInvalidResource
+		}
+		for 0 < len(stack) && indent <= stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		keys := make([]string, 0, len(stack)+1)
+		for _, l := range stack {
+			keys = append(keys, l.key)
+		}
+		keys = append(keys, key)
+		entry := ResourceEntry{Key: strings.Join(keys, "."), Path: keys}
+
+		// block returns the following lines belonging to the entry, without its indentation
+		block := func(belongs func(line string) bool) (block []string) {
+			for idx+1 < len(lines) {
+				next := strings.TrimRight(lines[idx+1], " \t")
+				if strings.TrimSpace(next) != "" && !belongs(next) {
+					break
+				}
+				if indent < len(next) {
+					next = next[indent:]
+				} else {
+					next = strings.TrimLeft(next, " ")
+				}
+				block = append(block, next)
+				idx++
+			}
+			for 0 < len(block) && block[len(block)-1] == "" {
+				block = block[:len(block)-1]
+			}
+			return
+		}
+
+		switch {
+		case rest == "":
+			next := ""
+			for _, l := range lines[idx+1:] {
+				if t := strings.TrimSpace(l); t != "" && !strings.HasPrefix(t, "#") {
+					next = l
+					break
+				}
+			}
+			switch t := strings.TrimSpace(next); {
+			case next == "" || indentOf(next) < indent || indentOf(next) == indent && !strings.HasPrefix(t, "-"):
+				// a key without its value is null
+				entry.Raw = "~"
+			case t != "-" && !strings.HasPrefix(t, "- "):
+				stack = append(stack, level{indent: indent, key: key})
+				continue
+			default:
+				// a block list is kept as it is
+				entry.Raw = "\n" + strings.Join(block(func(l string) bool {
+					n := indentOf(l)
+					return indent < n || n == indent && strings.HasPrefix(strings.TrimSpace(l), "-")
+				}), "\n")
+			}
+		case rest[0] == '|' || rest[0] == '>':
+			body := block(func(l string) bool { return indent < indentOf(l) })
+			margin := -1
+			for _, l := range body {
+				if n := indentOf(l); strings.TrimSpace(l) != "" && (margin < 0 || n < margin) {
+					margin = n
+				}
+			}
+			for i, l := range body {
+				if margin < len(l) {
+					body[i] = l[margin:]
+				} else {
+					body[i] = strings.TrimLeft(l, " ")
+				}
+			}
+			sep := "\n"
+			if rest[0] == '>' {
+				sep = " "
+			}
+			entry.Value = strings.Join(body, sep)
+			if !strings.HasSuffix(rest, "-") {
+				entry.Value += "\n"
+			}
+		case rest[0] == '"':
+			if entry.Value, err = strconv.Unquote(rest); err != nil {
+				return r, ErrInvalidResource
+			}
+		case rest[0] == '\'':
+			if len(rest) < 2 || !strings.HasSuffix(rest, "'") {
+				return r, ErrInvalidResource
+			}
+			entry.Value = strings.ReplaceAll(rest[1:len(rest)-1], "''", "'")
+		default:
+			if pos := strings.Index(rest, " #"); 0 <= pos {
+				rest = strings.TrimSpace(rest[:pos])
+			}
+			if strings.ContainsAny(rest[:1], "[{&*!") || yamlScalarPattern.MatchString(rest) {
+				entry.Raw = rest
+			} else {
+				entry.Value = rest
+			}
+		}
+
+		entry.Comment, comments = strings.Join(comments, "\n"), nil
+		r.Entries = append(r.Entries, entry)
+	}
+	r.Footer = strings.Join(comments, "\n")
+	return
+}
+
+// splitYAMLKey splits @line into its key and the rest after the colon.
+func splitYAMLKey(line string) (key, rest string, ok bool) {
+	if line[0] == '"' || line[0] == '\'' {
+		end := strings.IndexByte(line[1:], line[0])
+		if end < 0 || !strings.HasPrefix(line[end+2:], ":") {
+			return
+		}
+		key, rest = line[1:end+1], line[end+3:]
+	} else {
+		pos := strings.Index(line, ": ")
+		switch {
+		case 0 < pos:
+			key, rest = line[:pos], line[pos+2:]
+		case strings.HasSuffix(line, ":"):
+			key = line[:len(line)-1]
+		default:
+			return
+		}
+	}
+	return key, strings.TrimSpace(rest), !strings.HasPrefix(key, "- ")
+}
+
+func (r Resource) renderYAML() string {
+	var (
+		sb     strings.Builder
+		render func(node *resourceNode, indent string)
+	)
+	comment := func(text, indent string) {
+		if text != "" {
+			for _, line := range strings.Split(text, "\n") {
+				sb.WriteString(strings.TrimRight(indent+"# "+line, " ") + "\n")
+			}
+		}
+	}
+	render = func(node *resourceNode, indent string) {
+		for _, child := range node.children {
+			key := child.key
+			if !yamlPlainKeyPattern.MatchString(key) {
+				key = strconv.Quote(key)
+			}
+			switch entry := child.entry; {
+			case entry == nil:
+				sb.WriteString(indent + key + ":\n")
+				render(child, indent+"  ")
+			case strings.HasPrefix(entry.Raw, "\n"):
+				comment(entry.Comment, indent)
+				sb.WriteString(indent + key + ":\n")
+				for _, line := range strings.Split(entry.Raw[1:], "\n") {
+					sb.WriteString(strings.TrimRight(indent+line, " ") + "\n")
+				}
+			case entry.Raw != "":
+				comment(entry.Comment, indent)
+				sb.WriteString(indent + key + ": " + entry.Raw + "\n")
+			default:
+				comment(entry.Comment, indent)
+				sb.WriteString(indent + key + ": " + yamlString(entry.Value) + "\n")
+			}
+		}
+	}
+	render(resourceTree(r.Entries), "")
+	comment(r.Footer, "")
+	return sb.String()
+}
+
+// yamlString returns @s as a YAML scalar, quoted unless it is read back as it is.
+func yamlString(s string) string {
+	if s == "" || s != strings.TrimSpace(s) || strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") ||
+		strings.ContainsAny(s, "\n\t") || strings.Contains(s, ": ") || strings.Contains(s, " #") ||
+		strings.HasSuffix(s, ":") || yamlScalarPattern.MatchString(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func parsePOResource(text string) (r Resource, err error) {
+	var (
+		entry    ResourceEntry
+		comments []string
+		raw      []string
+		field    *string
+		plural   bool
+		started  bool
+		id       bool
+	)
+	flush := func() {
+		if started {
+			entry.Comment = strings.Join(comments, "\n")
+			if plural {
+				entry.Comment, entry.Value, entry.Raw = "", "", strings.Join(raw, "\n")
+			}
+			r.Entries = append(r.Entries, entry)
+		} else if 0 < len(comments) {
+			return
+		}
+		entry, comments, raw, field, plural, started, id = ResourceEntry{}, nil, nil, nil, false, false, false
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		keyword := strings.SplitN(line, " ", 2)[0]
+		var value string
+		if strings.HasPrefix(line, "\"") || strings.HasPrefix(keyword, "msg") {
+			quoted := strings.TrimSpace(strings.TrimPrefix(line, keyword))
+			if strings.HasPrefix(line, "\"") {
+				quoted = line
+			}
+			if value, err = strconv.Unquote(quoted); err != nil {
+				return r, ErrInvalidResource
+			}
+		}
+
+		switch {
+		case line == "":
+			flush()
+			continue
+		case strings.HasPrefix(line, "#"):
+			if started {
+				flush()
+			}
+			comments = append(comments, line)
+		case keyword == "msgctxt":
+			if started {
+				flush()
+			}
+			started = true
+			entry.Context, field = value, &entry.Context
+		case keyword == "msgid":
+			if id {
+				flush()
+			}
+			started, id = true, true
+			entry.Key, field = value, &entry.Key
+		case keyword == "msgid_plural" || strings.HasPrefix(keyword, "msgstr["):
+			plural, field = true, nil
+		case keyword == "msgstr":
+			entry.Value, field = value, &entry.Value
+		case strings.HasPrefix(line, "\""):
+			if field != nil {
+				*field += value
+			}
+		default:
+			return r, ErrInvalidResource
+		}
+		raw = append(raw, line)
+	}
+	flush()
+	if 0 < len(comments) {
+		r.Footer = strings.Join(comments, "\n")
+	}
+	return
+}
+
+func (r Resource) renderPO() string {
+	var sb strings.Builder
+	field := func(keyword, value string) {
+		lines := strings.SplitAfter(value, "\n")
+		if lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		}
+		if len(lines) <= 1 {
+			sb.WriteString(keyword + " " + poString(value) + "\n")
+			return
+		}
+		sb.WriteString(keyword + " \"\"\n")
+		for _, line := range lines {
+			sb.WriteString(poString(line) + "\n")
+		}
+	}
+
+	for idx, entry := range r.Entries {
+		if 0 < idx {
+			sb.WriteString("\n")
+		}
+		if entry.Raw != "" {
+			sb.WriteString(entry.Raw + "\n")
+			continue
+		}
+		if entry.Comment != "" {
+			sb.WriteString(entry.Comment + "\n")
+		}
+		if entry.Context != "" {
+			field("msgctxt", entry.Context)
+		}
+		field("msgid", entry.Key)
+		field("msgstr", entry.Value)
+	}
+	if r.Footer != "" {
+		sb.WriteString("\n" + r.Footer + "\n")
+	}
+	return sb.String()
+}
+
+var poEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+
+// poString returns @s quoted as a PO string.
+func poString(s string) string { return `"` + poEscaper.Replace(s) + `"` }
+
+func parseAndroidResource(text string) (r Resource, err error) {
+	start := strings.Index(text, "<resources")
+	if start < 0 {
+		return r, ErrInvalidResource
+	}
+	gt := strings.IndexByte(text[start:], '>')
+	if gt < 0 {
+		return r, ErrInvalidResource
+	}
+	r.Header = text[:start+gt+1]
+	if strings.HasSuffix(r.Header, "/>") {
+		r.Header = strings.TrimSuffix(r.Header, "/>") + ">"
+		return
+	}
+
+	body := text[start+gt+1:]
+	end := strings.LastIndex(body, "</resources>")
+	if end < 0 {
+		return r, ErrInvalidResource
+	}
+	body = body[:end]
+
+	var comments []string
+	for pos := 0; ; {
+		for pos < len(body) && strings.ContainsRune(" \t\n", rune(body[pos])) {
+			pos++
+		}
+		if len(body) <= pos {
+			break
+		}
+
+		if strings.HasPrefix(body[pos:], "<!--") {
+			end := strings.Index(body[pos:], "-->")
+			if end < 0 {
+				return r, ErrInvalidResource
+			}
+			comments = append(comments, strings.TrimSpace(body[pos+4:pos+end]))
+			pos += end + 3
+			continue
+		}
+		if body[pos] != '<' {
+			return r, ErrInvalidResource
+		}
+
+		gt := strings.IndexByte(body[pos:], '>')
+		if gt < 0 {
+			return r, ErrInvalidResource
+		}
+		gt += pos
+		tag := strings.TrimSuffix(body[pos+1:gt], "/")
+		if len(strings.Fields(tag)) == 0 {
+			return r, ErrInvalidResource
+		}
+		name := strings.Fields(tag)[0]
+		attrs := strings.TrimSpace(tag[len(name):])
+
+		next, inner := gt+1, ""
+		if body[gt-1] != '/' {
+			closing := strings.Index(body[gt+1:], "</"+name)
+			if closing < 0 {
+				return r, ErrInvalidResource
+			}
+			inner = body[gt+1 : gt+1+closing]
+			closeEnd := strings.IndexByte(body[gt+1+closing:], '>')
+			if closeEnd < 0 {
+				return r, ErrInvalidResource
+			}
+			next = gt + 1 + closing + closeEnd + 1
+		}
+
+		var entry ResourceEntry
+		if m := androidNamePattern.FindStringSubmatch(attrs); m != nil {
+			entry.Key = m[1]
+		}
+		if name == "string" {
+			entry.Attrs = strings.TrimSpace(androidNamePattern.ReplaceAllString(attrs, ""))
+			entry.Value = inner
+		} else {
+			entry.Context, entry.Raw = name, body[pos:next]
+		}
+		entry.Comment, comments = strings.Join(comments, "\n"), nil
+		r.Entries = append(r.Entries, entry)
+		pos = next
+	}
+	r.Footer = strings.Join(comments, "\n")
+	return
+}
+
+func (r Resource) renderAndroid() string {
+	var sb strings.Builder
+	comment := func(text string) {
+		if text != "" {
+			for _, line := range strings.Split(text, "\n") {
+				sb.WriteString("    <!-- " + line + " -->\n")
+			}
+		}
+	}
+
+	header := r.Header
+	if header == "" {
+		header = "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources>"
+	}
+	sb.WriteString(header + "\n")
+	for _, entry := range r.Entries {
+		comment(entry.Comment)
+		if entry.Raw != "" {
+			sb.WriteString("    " + entry.Raw + "\n")
+			continue
+		}
+		sb.WriteString(`    <string name="` + entry.Key + `"`)
+		if entry.Attrs != "" {
+			sb.WriteString(" " + entry.Attrs)
+		}
+		sb.WriteString(">" + entry.Value + "</string>\n")
+	}
+	comment(r.Footer)
+	sb.WriteString("</resources>\n")
+	return sb.String()
+}
+
+var (
+	androidEscaper      = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "'", `\'`, `"`, `\"`)
+	androidCDATAEscaper = strings.NewReplacer("'", `\'`, `"`, `\"`)
+)
+
+// escapeAndroid escapes the translated text of an Android string.
+func escapeAndroid(s string) string { return androidEscaper.Replace(s) }
+
+// escapeAndroidCDATA escapes the translated text of an Android string within a CDATA section,
+// where only the quotes are escaped.
+func escapeAndroidCDATA(s string) string { return androidCDATAEscaper.Replace(s) }
+
+func parseStringsResource(text string) (r Resource, err error) {
+	var comments []string
+	skip := func(pos int) int {
+		for pos < len(text) && unicode.IsSpace(rune(text[pos])) {
+			pos++
+		}
+		return pos
+	}
+
+	for pos := skip(0); pos < len(text); pos = skip(pos) {
+		switch {
+		case strings.HasPrefix(text[pos:], "/*"):
+			end := strings.Index(text[pos:], "*/")
+			if end < 0 {
+				return r, ErrInvalidResource
+			}
+			comments = append(comments, strings.TrimSpace(text[pos+2:pos+end]))
+			pos += end + 2
+			continue
+		case strings.HasPrefix(text[pos:], "//"):
+			end := strings.IndexByte(text[pos:], '\n')
+			if end < 0 {
+				end = len(text) - pos
+			}
+			comments = append(comments, strings.TrimSpace(text[pos+2:pos+end]))
+			pos += end
+			continue
+		}
+
+		var entry ResourceEntry
+		if entry.Key, pos, err = readStringsToken(text, pos); err != nil {
+			return
+		}
+		if pos = skip(pos); pos == len(text) || text[pos] != '=' {
+			return r, ErrInvalidResource
+		}
+		if entry.Value, pos, err = readStringsToken(text, skip(pos+1)); err != nil {
+			return
+		}
+		if pos = skip(pos); pos == len(text) || text[pos] != ';' {
+			return r, ErrInvalidResource
+		}
+		pos++
+
+		entry.Comment, comments = strings.Join(comments, "\n"), nil
+		r.Entries = append(r.Entries, entry)
+	}
+	r.Footer = strings.Join(comments, "\n")
+	return
+}
+
+// readStringsToken reads either a quoted string or an unquoted word of a .strings file from @pos of @text.
+func readStringsToken(text string, pos int) (string, int, error) {
+	if pos == len(text) {
+		return "", pos, ErrInvalidResource
+	}
+	if text[pos] != '"' {
+		end := pos
+		for end < len(text) && (text[end] == '_' || text[end] == '.' || unicode.IsLetter(rune(text[end])) || unicode.IsDigit(rune(text[end]))) {
+			end++
+		}
+		if end == pos {
+			return "", pos, ErrInvalidResource
+		}
+		return text[pos:end], end, nil
+	}
+
+	var sb strings.Builder
+	for idx := pos + 1; idx < len(text); idx++ {
+		switch ch := text[idx]; ch {
+		case '"':
+			return sb.String(), idx + 1, nil
+		case '\\':
+			if idx+1 == len(text) {
+				return "", pos, ErrInvalidResource
+			}
+			idx++
+			switch text[idx] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case 'u', 'U':
+				if len(text) < idx+5 {
+					return "", pos, ErrInvalidResource
+				}
+				n, err := strconv.ParseUint(text[idx+1:idx+5], 16, 32)
+				if err != nil {
+					return "", pos, ErrInvalidResource
+				}
+				sb.WriteRune(rune(n))
+				idx += 4
+			default:
+				sb.WriteByte(text[idx])
+			}
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return "", pos, ErrInvalidResource
+}
+
+func (r Resource) renderStrings() string {
+	var sb strings.Builder
+	comment := func(text string) {
+		if text != "" {
+			sb.WriteString("/* " + strings.ReplaceAll(text, "\n", "\n   ") + " */\n")
+		}
+	}
+	for idx, entry := range r.Entries {
+		if 0 < idx && entry.Comment != "" {
+			sb.WriteString("\n")
+		}
+		comment(entry.Comment)
+		sb.WriteString(stringsString(entry.Key) + " = " + stringsString(entry.Value) + ";\n")
+	}
+	if r.Footer != "" {
+		sb.WriteString("\n")
+		comment(r.Footer)
+	}
+	return sb.String()
+}
+
+// stringsString returns @s quoted as a string of a .strings file.
+func stringsString(s string) string { return `"` + poEscaper.Replace(s) + `"` }
+
+// ResourceTranslateInitializer is a lazy translator of localization resources.
+type ResourceTranslateInitializer struct {
+	Source   Resource
+	Target   Resource
+	Document *DocumentTranslateInitializer
+}
+
+// TranslateResource translates the entries of @src missing in @dst.
+//
+// An entry is missing if @dst does not have its key or has it with an empty value,
+// and it is translated from its value, or from its msgid for PO.
+// The format placeholders, such as %s, %1$d and {name}, are kept as they are.
+//
+// The entries of @dst keep their order, followed by the new ones in the order of @src.
+// The raw entries, such as plurals and arrays, are added as they are rather than translated,
+// and the Android strings not translatable are left out.
+func TranslateResource(src, dst Resource) *ResourceTranslateInitializer {
+	return &ResourceTranslateInitializer{
+		Source:   src,
+		Target:   dst,
+		Document: TranslateDocument(""),
+	}
+}
+
+// AuthorizeWith sets the authorization key to @key.
+func (ri *ResourceTranslateInitializer) AuthorizeWith(key string) *ResourceTranslateInitializer {
+	ri.Document.AuthorizeWith(key)
+	return ri
+}
+
+// From sets the source language of the resource to @src.
+//
+// See TranslateInitializer.From for the available languages.
+func (ri *ResourceTranslateInitializer) From(src string) *ResourceTranslateInitializer {
+	ri.Document.From(src)
+	return ri
+}
+
+// To sets the target language of the resource to @target.
+//
+// See TranslateInitializer.To for the available languages.
+func (ri *ResourceTranslateInitializer) To(target string) *ResourceTranslateInitializer {
+	ri.Document.To(target)
+	return ri
+}
+
+// WithGlossary makes the translator translate the terms of @g as it specifies.
+//
+// See TranslateInitializer.WithGlossary for more details.
+func (ri *ResourceTranslateInitializer) WithGlossary(g *Glossary) *ResourceTranslateInitializer {
+	ri.Document.WithGlossary(g)
+	return ri
+}
+
+// WithMemory makes the translator reuse the translations in @tm and add the new ones to it.
+//
+// See DocumentTranslateInitializer.WithMemory for more details.
+func (ri *ResourceTranslateInitializer) WithMemory(tm *TranslationMemory) *ResourceTranslateInitializer {
+	ri.Document.WithMemory(tm)
+	return ri
+}
+
+// Concurrency sets the maximum number of concurrent requests to @workers (a value between 1 and 32).
+func (ri *ResourceTranslateInitializer) Concurrency(workers int) *ResourceTranslateInitializer {
+	ri.Document.Concurrency(workers)
+	return ri
+}
+
+// RateLimit limits the requests to @n per second.
+func (ri *ResourceTranslateInitializer) RateLimit(n int) *ResourceTranslateInitializer {
+	ri.Document.RateLimit(n)
+	return ri
+}
+
+// Collect returns the target resource with the missing entries translated.
+func (ri *ResourceTranslateInitializer) Collect() (res Resource, err error) {
+	res = ri.Target
+	if res.Format == "" {
+		res.Format = ri.Source.Format
+	}
+	res.Entries = append([]ResourceEntry{}, ri.Target.Entries...)
+
+	index := map[string]int{}
+	for idx, entry := range res.Entries {
+		index[entry.id()] = idx
+	}
+
+	var (
+		pending []int
+		units   []*unit
+		lines   []string
+	)
+	for _, entry := range ri.Source.Entries {
+		pos, ok := index[entry.id()]
+		if ok && (res.Entries[pos].Value != "" || res.Entries[pos].Raw != "") {
+			continue
+		}
+		switch {
+		case entry.Raw != "":
+			if !ok {
+				index[entry.id()] = len(res.Entries)
+				res.Entries = append(res.Entries, entry)
+			}
+			continue
+		case androidUntranslatedPattern.MatchString(entry.Attrs), ri.Source.Format == "po" && entry.Key == "":
+			continue
+		}
+
+		text := entry.Value
+		if text == "" && ri.Source.Format == "po" {
+			text = entry.Key
+		}
+		if text == "" {
+			continue
+		}
+		if !ok {
+			entry.Value = ""
+			pos = len(res.Entries)
+			index[entry.id()] = pos
+			res.Entries = append(res.Entries, entry)
+		}
+
+		// the entries with nothing to translate are copied as they are
+		u := &unit{escape: noEscape}
+		if res.Format == "xml" {
+			if strings.Contains(text, "<![CDATA[") {
+				u.escape = escapeAndroidCDATA
+			} else {
+				u.escape = escapeAndroid
+			}
+		}
+		masked := resourcePattern.ReplaceAllStringFunc(text, u.mask)
+		if strings.IndexFunc(placeholderPattern.ReplaceAllString(masked, ""), unicode.IsLetter) < 0 {
+			res.Entries[pos].Value = text
+			continue
+		}
+		pending, units, lines = append(pending, pos), append(units, u), append(lines, masked)
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	di := *ri.Document
+	di.Text = strings.Join(lines, "\n")
+	tr, err := di.Collect()
+	if err != nil {
+		return
+	}
+
+	for idx, pos := range pending {
+		if idx < len(tr.TranslatedText) && 0 < len(tr.TranslatedText[idx]) {
+			u := units[idx]
+			res.Entries[pos].Value = u.restore(u.escape(strings.Join(tr.TranslatedText[idx], " ")))
+		}
+	}
+	return
+}
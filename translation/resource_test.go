@@ -0,0 +1,163 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation_test
+
+import (
+	"internal/common"
+	"path/filepath"
+	"testing"
+
+	"github.com/maengsanha/kakao-developers-client/translation"
+)
+
+var resources = map[string]string{
+	"json": `{
+  "app": {
+    "title": "Hello, {name}!",
+    "count": 3
+  },
+  "flat.key": "It's <b>%1$s</b>"
+}
+`,
+	"yaml": `en:
+  # greeting
+  greeting: Hello %{name}
+  quoted: "a: b"
+  days:
+  - Sun
+  - Mon
+`,
+	"po": `# header
+msgid ""
+msgstr "Language: ko\n"
+
+#. extracted
+msgid "Open %s"
+msgstr ""
+
+msgctxt "menu"
+msgid "File"
+msgstr "파일"
+`,
+	"xml": `<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <!-- App name -->
+    <string name="app_name" translatable="false">Demo</string>
+    <string name="hi">Don\'t %1$s &amp; go</string>
+    <plurals name="files">
+        <item quantity="other">%d files</item>
+    </plurals>
+</resources>
+`,
+	"strings": `/* Title */
+"title" = "Hello \"%@\"";
+"body" = "Line\nTwo";
+`,
+}
+
+func TestParseResource(t *testing.T) {
+	for format, text := range resources {
+		r, err := translation.ParseResource(text, format)
+		if err != nil {
+			t.Fatal(format, err)
+		}
+		if r.String() != text {
+			t.Errorf("%s: expected %q, got %q", format, text, r.String())
+		}
+	}
+
+	r, err := translation.ParseResource(resources["po"], "pot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Entries[1].Key != "Open %s" || r.Entries[1].Comment != "#. extracted" || r.Entries[2].Context != "menu" {
+		t.Errorf("unexpected entries: %+v", r.Entries)
+	}
+
+	filename := filepath.Join(t.TempDir(), "strings.xml")
+	if err := r.SaveAs(filename); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, err := translation.LoadResource(filename); err != nil || loaded.Format != "xml" || len(loaded.Entries) != 3 {
+		t.Errorf("unexpected resource: %+v, %v", loaded, err)
+	}
+
+	if _, err := translation.ParseResource(`"title" = "Hello"`, "strings"); err != translation.ErrInvalidResource {
+		t.Errorf("expected ErrInvalidResource, got %v", err)
+	}
+}
+
+func TestTranslateResourceKeepsPlaceholders(t *testing.T) {
+	src, err := translation.ParseResource(resources["json"], "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst, err := translation.ParseResource(`<resources>
+    <string name="app.title">Hi, {name}!</string>
+</resources>`, "xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := translation.TranslateResource(src, dst).From("en").To("en").Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Entries) != 3 || res.Entries[0].Value != "Hi, {name}!" {
+		t.Fatalf("unexpected entries: %+v", res.Entries)
+	}
+	if res.Entries[1].Raw != "3" || res.Entries[2].Value != `It\'s <b>%1$s</b>` {
+		t.Errorf("unexpected entries: %+v", res.Entries[1:])
+	}
+}
+
+func TestTranslateAndroidResource(t *testing.T) {
+	src, err := translation.ParseResource(`<resources>
+    <string name="cdata"><![CDATA[<b>Hello</b> & it\'s <a href="https://example.com">here</a>]]></string>
+    <string name="escaped">Don\'t &amp; <i>go</i> \"now\"</string>
+</resources>
+`, "xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := src
+	dst.Entries = nil
+
+	res, err := translation.TranslateResource(src, dst).From("en").To("en").Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.String() != src.String() {
+		t.Errorf("expected %s, got %s", src, res)
+	}
+}
+
+func TestTranslateResource(t *testing.T) {
+	src, err := translation.ParseResource(resources["strings"], "strings")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := translation.TranslateResource(src, translation.Resource{}).
+		From("en").
+		To("kr").
+		AuthorizeWith(common.REST_API_KEY).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Log(res)
+}
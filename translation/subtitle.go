@@ -0,0 +1,375 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation
+
+import (
+	"fmt"
+	"internal/common"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Cue represents a cue of a subtitle.
+//
+// Settings holds the WebVTT cue settings following the timings, if any.
+type Cue struct {
+	ID       string        `json:"id"`
+	Start    time.Duration `json:"start"`
+	End      time.Duration `json:"end"`
+	Settings string        `json:"settings"`
+	Lines    []string      `json:"lines"`
+}
+
+// Text returns the lines of c joined with spaces.
+func (c Cue) Text() string { return strings.Join(c.Lines, " ") }
+
+// Subtitle represents a subtitle in either the SRT or WebVTT format.
+//
+// Header holds the WebVTT header and the blocks before the first cue.
+// The NOTE, STYLE and REGION blocks between the cues are not kept.
+type Subtitle struct {
+	Format string `json:"format"`
+	Header string `json:"header"`
+	Cues   []Cue  `json:"cues"`
+}
+
+// ParseSubtitle parses @text in either the SRT or WebVTT format.
+//
+// A text starting with WEBVTT is parsed as WebVTT, and otherwise as SRT.
+func ParseSubtitle(text string) (sub Subtitle, err error) {
+	text = strings.TrimPrefix(strings.ReplaceAll(text, "\r\n", "\n"), "\ufeff")
+
+	sub.Format = "srt"
+	if strings.HasPrefix(text, "WEBVTT") {
+		sub.Format = "vtt"
+	}
+
+	for idx, block := range splitBlocks(text) {
+		lines := strings.Split(block, "\n")
+		if sub.Format == "vtt" {
+			switch first := strings.Fields(lines[0]); {
+			case idx == 0:
+				sub.Header = block
+				continue
+			case 0 < len(first) && (first[0] == "NOTE" || first[0] == "STYLE" || first[0] == "REGION"):
+				if len(sub.Cues) == 0 {
+					sub.Header += "\n\n" + block
+				}
+				continue
+			}
+		}
+
+		var cue Cue
+		if !strings.Contains(lines[0], "-->") {
+			cue.ID, lines = strings.TrimSpace(lines[0]), lines[1:]
+		}
+		if len(lines) == 0 {
+			return sub, ErrInvalidSubtitle
+		}
+		if cue.Start, cue.End, cue.Settings, err = parseTimings(lines[0]); err != nil {
+			return
+		}
+		cue.Lines = append([]string{}, lines[1:]...)
+		sub.Cues = append(sub.Cues, cue)
+	}
+	return
+}
+
+// LoadSubtitle loads the subtitle in @filename.
+//
+// @filename should end with .srt or .vtt.
+func LoadSubtitle(filename string) (sub Subtitle, err error) {
+	switch tokens := strings.Split(filename, "."); tokens[len(tokens)-1] {
+	case "srt", "vtt":
+		bs, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return sub, err
+		}
+		return ParseSubtitle(string(bs))
+	default:
+		return sub, common.ErrUnsupportedFormat
+	}
+}
+
+// String renders sub in its format.
+func (sub Subtitle) String() string {
+	var sb strings.Builder
+	if sub.Format == "vtt" {
+		header := sub.Header
+		if header == "" {
+			header = "WEBVTT"
+		}
+		sb.WriteString(header + "\n\n")
+	}
+
+	for idx, cue := range sub.Cues {
+		if 0 < idx {
+			sb.WriteString("\n")
+		}
+		switch sub.Format {
+		case "vtt":
+			if cue.ID != "" {
+				sb.WriteString(cue.ID + "\n")
+			}
+			sb.WriteString(formatTimestamp(cue.Start, '.') + " --> " + formatTimestamp(cue.End, '.'))
+			if cue.Settings != "" {
+				sb.WriteString(" " + cue.Settings)
+			}
+		default:
+			if _, err := strconv.Atoi(cue.ID); err == nil {
+				sb.WriteString(cue.ID + "\n")
+			} else {
+				sb.WriteString(strconv.Itoa(idx+1) + "\n")
+			}
+			sb.WriteString(formatTimestamp(cue.Start, ',') + " --> " + formatTimestamp(cue.End, ','))
+		}
+		sb.WriteString("\n")
+		for _, line := range cue.Lines {
+			sb.WriteString(line + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// SaveAs saves sub to @filename.
+//
+// @filename should end with .srt or .vtt, which decides the format of the file.
+func (sub Subtitle) SaveAs(filename string) error {
+	switch tokens := strings.Split(filename, "."); tokens[len(tokens)-1] {
+	case "srt", "vtt":
+		sub.Format = tokens[len(tokens)-1]
+		return ioutil.WriteFile(filename, []byte(sub.String()), 0o644)
+	default:
+		return common.ErrUnsupportedFormat
+	}
+}
+
+// splitBlocks splits @text into the blocks separated by blank lines.
+func splitBlocks(text string) (blocks []string) {
+	var cur []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if 0 < len(cur) {
+				blocks = append(blocks, strings.Join(cur, "\n"))
+				cur = nil
+			}
+			continue
+		}
+		cur = append(cur, line)
+	}
+	if 0 < len(cur) {
+		blocks = append(blocks, strings.Join(cur, "\n"))
+	}
+	return
+}
+
+// parseTimings parses the timing line of a cue, such as "00:00:01,000 --> 00:00:02,500".
+func parseTimings(line string) (start, end time.Duration, settings string, err error) {
+	tokens := strings.Fields(line)
+	if len(tokens) < 3 || tokens[1] != "-->" {
+		return 0, 0, "", ErrInvalidSubtitle
+	}
+	if start, err = parseTimestamp(tokens[0]); err != nil {
+		return
+	}
+	if end, err = parseTimestamp(tokens[2]); err != nil {
+		return
+	}
+	settings = strings.Join(tokens[3:], " ")
+	return
+}
+
+// parseTimestamp parses @ts in the form of [hh:]mm:ss,mmm or [hh:]mm:ss.mmm.
+func parseTimestamp(ts string) (time.Duration, error) {
+	ts = strings.Replace(ts, ",", ".", 1)
+	dot := strings.LastIndexByte(ts, '.')
+	if dot < 0 {
+		return 0, ErrInvalidSubtitle
+	}
+	millis, err := strconv.Atoi(ts[dot+1:])
+	if err != nil || len(ts[dot+1:]) != 3 {
+		return 0, ErrInvalidSubtitle
+	}
+
+	parts := strings.Split(ts[:dot], ":")
+	if len(parts) < 2 || 3 < len(parts) {
+		return 0, ErrInvalidSubtitle
+	}
+	d := time.Duration(millis) * time.Millisecond
+	unit := time.Second
+	for idx := len(parts) - 1; 0 <= idx; idx-- {
+		n, err := strconv.Atoi(parts[idx])
+		if err != nil {
+			return 0, ErrInvalidSubtitle
+		}
+		d += time.Duration(n) * unit
+		unit *= 60
+	}
+	return d, nil
+}
+
+// formatTimestamp formats @d in the form of hh:mm:ss followed by @sep and milliseconds.
+func formatTimestamp(d time.Duration, sep byte) string {
+	millis := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d",
+		millis/3600000, millis/60000%60, millis/1000%60, sep, millis%1000)
+}
+
+// SubtitleTranslateInitializer is a lazy subtitle translator.
+type SubtitleTranslateInitializer struct {
+	Subtitle Subtitle
+	Document *DocumentTranslateInitializer
+}
+
+// TranslateSubtitle translates the cues of @sub.
+//
+// The text of each cue is translated as a whole and broken into as many lines as the original,
+// while the timings and the numbering are kept.
+func TranslateSubtitle(sub Subtitle) *SubtitleTranslateInitializer {
+	texts := make([]string, len(sub.Cues))
+	for idx, cue := range sub.Cues {
+		texts[idx] = cue.Text()
+	}
+	return &SubtitleTranslateInitializer{
+		Subtitle: sub,
+		Document: TranslateDocument(strings.Join(texts, "\n")),
+	}
+}
+
+// AuthorizeWith sets the authorization key to @key.
+func (si *SubtitleTranslateInitializer) AuthorizeWith(key string) *SubtitleTranslateInitializer {
+	si.Document.AuthorizeWith(key)
+	return si
+}
+
+// From sets the source language of the subtitle to @src.
+//
+// See TranslateInitializer.From for the available languages.
+func (si *SubtitleTranslateInitializer) From(src string) *SubtitleTranslateInitializer {
+	si.Document.From(src)
+	return si
+}
+
+// To sets the target language of the subtitle to @target.
+//
+// See TranslateInitializer.To for the available languages.
+func (si *SubtitleTranslateInitializer) To(target string) *SubtitleTranslateInitializer {
+	si.Document.To(target)
+	return si
+}
+
+// WithGlossary makes the translator translate the terms of @g as it specifies.
+//
+// See TranslateInitializer.WithGlossary for more details.
+func (si *SubtitleTranslateInitializer) WithGlossary(g *Glossary) *SubtitleTranslateInitializer {
+	si.Document.WithGlossary(g)
+	return si
+}
+
+// WithMemory makes the translator reuse the translations in @tm and add the new ones to it.
+//
+// See DocumentTranslateInitializer.WithMemory for more details.
+func (si *SubtitleTranslateInitializer) WithMemory(tm *TranslationMemory) *SubtitleTranslateInitializer {
+	si.Document.WithMemory(tm)
+	return si
+}
+
+// Concurrency sets the maximum number of concurrent requests to @workers (a value between 1 and 32).
+func (si *SubtitleTranslateInitializer) Concurrency(workers int) *SubtitleTranslateInitializer {
+	si.Document.Concurrency(workers)
+	return si
+}
+
+// RateLimit limits the requests to @n per second.
+func (si *SubtitleTranslateInitializer) RateLimit(n int) *SubtitleTranslateInitializer {
+	si.Document.RateLimit(n)
+	return si
+}
+
+// Collect returns the translated subtitle.
+func (si *SubtitleTranslateInitializer) Collect() (sub Subtitle, err error) {
+	tr, err := si.Document.Collect()
+	if err != nil {
+		return
+	}
+
+	sub = si.Subtitle
+	sub.Cues = make([]Cue, len(si.Subtitle.Cues))
+	paragraphs := tr.Paragraphs()
+	for idx, cue := range si.Subtitle.Cues {
+		if idx < len(paragraphs) && paragraphs[idx] != "" {
+			cue.Lines = wrap(paragraphs[idx], len(cue.Lines))
+		}
+		sub.Cues[idx] = cue
+	}
+	return
+}
+
+// wrap breaks @text into @n lines of similar lengths, at the spaces if possible.
+//
+// A text with fewer words than @n is broken into fewer lines, one word per line,
+// unless it is a single word of a script written without spaces, such as Japanese or Chinese.
+func wrap(text string, n int) []string {
+	words := strings.Fields(text)
+	if n <= 1 || len(words) == 0 {
+		return []string{text}
+	}
+
+	if len(words) < n {
+		runes := []rune(words[0])
+		if 1 < len(words) || strings.IndexFunc(words[0], unspaced) < 0 {
+			return words
+		}
+		// break the word of a script without spaces anywhere
+		if len(runes) < n {
+			n = len(runes)
+		}
+		lines := make([]string, 0, n)
+		for idx := 0; idx < n; idx++ {
+			lines = append(lines, string(runes[len(runes)*idx/n:len(runes)*(idx+1)/n]))
+		}
+		return lines
+	}
+
+	var (
+		lines  = make([]string, 0, n)
+		cur    []string
+		length int
+		total  = utf8.RuneCountInString(strings.Join(words, " "))
+	)
+	for idx, word := range words {
+		cur = append(cur, word)
+		length += utf8.RuneCountInString(word) + 1
+
+		remaining := n - len(lines) - 1
+		if remaining == 0 {
+			continue
+		}
+		if length >= total*(len(lines)+1)/n || len(words)-idx-1 == remaining {
+			lines = append(lines, strings.Join(cur, " "))
+			cur = nil
+		}
+	}
+	return append(lines, strings.Join(cur, " "))
+}
+
+// unspaced reports whether @r is of a script written without spaces between the words.
+func unspaced(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Thai)
+}
@@ -0,0 +1,123 @@
+// Copyright 2022 Sanha Maeng, Soyang Baek, Jinmyeong Kim
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translation_test
+
+import (
+	"internal/common"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/maengsanha/kakao-developers-client/translation"
+)
+
+const srt = "1\r\n00:00:01,000 --> 00:00:02,500\r\n안녕하세요.\r\n\r\n2\r\n00:01:02,003 --> 01:00:00,000\r\n오늘은 날씨가\r\n정말 좋네요.\r\n"
+
+const vtt = `WEBVTT - Title
+
+NOTE a note
+
+intro
+00:01.000 --> 00:02.500 align:start
+Hello.
+
+00:00:03.000 --> 00:00:04.000
+Nice to meet you.
+`
+
+func TestParseSubtitle(t *testing.T) {
+	sub, err := translation.ParseSubtitle(srt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.Format != "srt" || len(sub.Cues) != 2 || sub.Cues[1].Start != time.Minute+2*time.Second+3*time.Millisecond ||
+		sub.Cues[1].End != time.Hour || len(sub.Cues[1].Lines) != 2 {
+		t.Fatalf("unexpected subtitle: %+v", sub)
+	}
+	expected := "1\n00:00:01,000 --> 00:00:02,500\n안녕하세요.\n\n2\n00:01:02,003 --> 01:00:00,000\n오늘은 날씨가\n정말 좋네요.\n"
+	if sub.String() != expected {
+		t.Errorf("expected %q, got %q", expected, sub.String())
+	}
+
+	sub, err = translation.ParseSubtitle(vtt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.Header != "WEBVTT - Title\n\nNOTE a note" || sub.Cues[0].ID != "intro" || sub.Cues[0].Settings != "align:start" {
+		t.Fatalf("unexpected subtitle: %+v", sub)
+	}
+
+	filename := filepath.Join(t.TempDir(), "subtitle_test.srt")
+	if err := sub.SaveAs(filename); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := translation.LoadSubtitle(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Format != "srt" || loaded.Cues[0].ID != "1" || loaded.Cues[1].ID != "2" {
+		t.Errorf("unexpected subtitle: %+v", loaded)
+	}
+
+	if _, err := translation.ParseSubtitle("1\n00:00:01 --> 00:00:02\nHello."); err != translation.ErrInvalidSubtitle {
+		t.Errorf("expected ErrInvalidSubtitle, got %v", err)
+	}
+}
+
+func TestTranslateSubtitleLineBreaks(t *testing.T) {
+	sub, err := translation.ParseSubtitle(srt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tm := translation.NewTranslationMemory("")
+	tm.Add("kr", "en", "안녕하세요.", "Hello there.")
+	tm.Add("kr", "en", "오늘은 날씨가 정말 좋네요.", "Sunny!")
+
+	translated, err := translation.TranslateSubtitle(sub).
+		From("kr").
+		To("en").
+		WithMemory(tm).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := translated.Cues[0].Lines; len(lines) != 1 || lines[0] != "Hello there." {
+		t.Errorf("unexpected lines: %q", lines)
+	}
+	if lines := translated.Cues[1].Lines; len(lines) != 1 || lines[0] != "Sunny!" {
+		t.Errorf("unexpected lines: %q", lines)
+	}
+}
+
+func TestTranslateSubtitle(t *testing.T) {
+	sub, err := translation.ParseSubtitle(srt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	translated, err := translation.TranslateSubtitle(sub).
+		From("kr").
+		To("en").
+		AuthorizeWith(common.REST_API_KEY).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(translated.Cues[1].Lines) != 2 || translated.Cues[1].End != sub.Cues[1].End {
+		t.Errorf("timings or line breaks are not preserved: %v", translated)
+	}
+	t.Log(translated)
+}
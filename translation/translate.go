@@ -22,18 +22,34 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/goccy/go-json"
 )
 
 // TranslateResult represents a translation result.
+//
+// TranslatedText holds the translated sentences of each paragraph, i.e. line, of the input text.
+//
+// Detected holds the language detected when translating from Auto,
+// and Glossary the terms of the glossary found in the text.
+//
+// FromMemory reports whether the translation is served by a translation memory,
+// and Suggestions holds the similar segments of the memory to be reviewed.
 type TranslateResult struct {
-	TranslatedText [][]string `json:"translated_text"`
+	TranslatedText [][]string    `json:"translated_text"`
+	Detected       *LanguageInfo `json:"detected,omitempty"`
+	Glossary       []GlossaryHit `json:"glossary,omitempty"`
+	FromMemory     bool          `json:"from_memory,omitempty"`
+	Suggestions    []FuzzyMatch  `json:"suggestions,omitempty"`
 }
 
 // String implements fmt.Stringer.
 func (tr TranslateResult) String() string { return common.String(tr) }
 
+// Text returns the translated text of tr, with a line per paragraph.
+func (tr TranslateResult) Text() string { return strings.Join(tr.Paragraphs(), "\n") }
+
 // SaveAs saves tr to @filename.
 //
 // The file extension must be .json.
@@ -45,6 +61,10 @@ type TranslateInitializer struct {
 	SrcLang    string
 	TargetLang string
 	AuthKey    string
+	Threshold  float64
+	Glossary   *Glossary
+	Memory     *TranslationMemory
+	Similarity float64
 }
 
 // Translate translates the input text into various languages.
@@ -54,8 +74,8 @@ type TranslateInitializer struct {
 //
 // For more details visit https://developers.kakao.com/docs/latest/en/translate/dev-guide#trans-sentence.
 func Translate(text string) *TranslateInitializer {
-	if 5000 < len(text) {
-		panic(errors.New("up to 5,000 characters are allowed"))
+	if MaxLength < utf8.RuneCountInString(strings.TrimSpace(text)) {
+		panic(ErrTooLongText)
 	}
 	if r := recover(); r != nil {
 		log.Panicln(r)
@@ -114,15 +134,14 @@ func (ti *TranslateInitializer) AuthorizeWith(key string) *TranslateInitializer
 // th: Thai
 //
 // tr: Turkish
+//
+// Auto detects the source language of the text.
 func (ti *TranslateInitializer) From(src string) *TranslateInitializer {
-	switch src {
-	case "kr", "en", "jp", "cn", "vi", "id", "ar", "bn", "de",
-		"es", "fr", "hi", "it", "ms", "nl", "pt", "ru", "th", "tr":
+	if src == Auto || supported(src) {
 		ti.SrcLang = src
-	default:
-		panic(errors.New(
-			`source language must be one of the following options:
-			kr, en, jp, cn, vi, id, ar, bn, de, es, fr, hi, it, ms, nl, pt, ru, th, tr`))
+	} else {
+		panic(errors.New("source language must be one of the following options:\n" +
+			Auto + ", " + strings.Join(Languages, ", ")))
 	}
 	if r := recover(); r != nil {
 		log.Panicln(r)
@@ -172,13 +191,55 @@ func (ti *TranslateInitializer) From(src string) *TranslateInitializer {
 //
 // tr: Turkish
 func (ti *TranslateInitializer) To(target string) *TranslateInitializer {
-	switch target {
-	case "kr", "en", "jp", "cn", "vi", "id", "ar", "bn", "de",
-		"es", "fr", "hi", "it", "ms", "nl", "pt", "ru", "th", "tr":
+	if supported(target) {
 		ti.TargetLang = target
-	default:
-		panic(errors.New(`target language must be one of the following options:
-		kr, en, jp, cn, vi, id, ar, bn, de, es, fr, hi, it, ms, nl, pt, ru, th, tr`))
+	} else {
+		panic(errors.New("target language must be one of the following options:\n" +
+			strings.Join(Languages, ", ")))
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return ti
+}
+
+// MinConfidence sets the minimum confidence of the language detected from Auto to @confidence (a value between 0 and 1).
+func (ti *TranslateInitializer) MinConfidence(confidence float64) *TranslateInitializer {
+	if 0 <= confidence && confidence <= 1 {
+		ti.Threshold = confidence
+	} else {
+		panic(ErrConfidenceOutOfBound)
+	}
+	if r := recover(); r != nil {
+		log.Panicln(r)
+	}
+	return ti
+}
+
+// WithGlossary makes the translator translate the terms of @g as it specifies.
+//
+// The glossary applies only if its languages match the ones of the translation.
+func (ti *TranslateInitializer) WithGlossary(g *Glossary) *TranslateInitializer {
+	ti.Glossary = g
+	return ti
+}
+
+// WithMemory makes the translator look up the text in @tm before calling the API,
+// and add the translation to @tm afterwards.
+//
+// The memory is not saved automatically, see TranslationMemory.Save.
+func (ti *TranslateInitializer) WithMemory(tm *TranslationMemory) *TranslateInitializer {
+	ti.Memory = tm
+	return ti
+}
+
+// Suggest makes the translator suggest the segments of its memory similar to the text
+// at least by @similarity (a value between 0 and 1), when the text is not found in the memory.
+func (ti *TranslateInitializer) Suggest(similarity float64) *TranslateInitializer {
+	if 0 < similarity && similarity <= 1 {
+		ti.Similarity = similarity
+	} else {
+		panic(ErrSimilarityOutOfBound)
 	}
 	if r := recover(); r != nil {
 		log.Panicln(r)
@@ -187,11 +248,47 @@ func (ti *TranslateInitializer) To(target string) *TranslateInitializer {
 }
 
 // Collect returns the translation result.
+//
+// If the source language is the same as the target language, the text is returned as it is.
+// If the text is found in the memory of the translator, its translation is returned without calling the API.
 func (ti *TranslateInitializer) Collect() (res TranslateResult, err error) {
+	src := ti.SrcLang
+	if src == Auto {
+		text, _ := url.QueryUnescape(ti.Query)
+		if src, res.Detected, err = ti.detect(text); err != nil {
+			return
+		}
+	}
+	if src == ti.TargetLang {
+		text, _ := url.QueryUnescape(ti.Query)
+		res.TranslatedText = untranslated(text)
+		return
+	}
+
+	if ti.Memory != nil {
+		text, _ := url.QueryUnescape(ti.Query)
+		if translation, ok := ti.Memory.Lookup(src, ti.TargetLang, text); ok {
+			res.TranslatedText, res.FromMemory = untranslated(translation), true
+			return
+		}
+		if 0 < ti.Similarity {
+			res.Suggestions = ti.Memory.Suggest(src, ti.TargetLang, text, ti.Similarity)
+		}
+	}
+
+	// the glossary terms are masked by placeholders during the translation
+	query, masked := ti.Query, (*unit)(nil)
+	if ti.Glossary != nil && ti.Glossary.applies(src, ti.TargetLang) {
+		text, _ := url.QueryUnescape(ti.Query)
+		masked = &unit{escape: noEscape}
+		text, res.Glossary = ti.Glossary.mask(text, masked)
+		query = url.QueryEscape(text)
+	}
+
 	client := &http.Client{}
 	req, err := http.NewRequest(http.MethodGet,
 		fmt.Sprintf("%s/v2/translation/translate?src_lang=%s&target_lang=%s&query=%s",
-			prefix, ti.SrcLang, ti.TargetLang, ti.Query), nil)
+			prefix, src, ti.TargetLang, query), nil)
 	if err != nil {
 		return
 	}
@@ -209,5 +306,13 @@ func (ti *TranslateInitializer) Collect() (res TranslateResult, err error) {
 	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
 		return
 	}
+
+	if masked != nil {
+		res.TranslatedText = masked.restoreParagraphs(res.TranslatedText)
+	}
+	if ti.Memory != nil {
+		text, _ := url.QueryUnescape(ti.Query)
+		ti.Memory.Add(src, ti.TargetLang, text, res.Text())
+	}
 	return
 }
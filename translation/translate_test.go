@@ -48,3 +48,32 @@ func TestTranslateWithSaveAsJSON(t *testing.T) {
 		t.Log(tr)
 	}
 }
+
+func TestTranslateCode(t *testing.T) {
+	for code, expected := range map[string]string{"ko": "kr", "kr": "kr", "ja": "jp", "zh-TW": "cn", "en": "en"} {
+		if translated, ok := translation.TranslateCode(code); !ok || translated != expected {
+			t.Errorf("%s: expected %s, got %s", code, expected, translated)
+		}
+	}
+	if _, ok := translation.TranslateCode("xx"); ok {
+		t.Error("unsupported language is accepted")
+	}
+}
+
+func TestTranslateFromAuto(t *testing.T) {
+	query := "이 대성당이라는 작품은 아주 짧은 시간 내에서의 한정된 공간의 사건을 다루고 있다."
+
+	tr, err := translation.Translate(query).
+		From(translation.Auto).
+		To("en").
+		MinConfidence(0.5).
+		AuthorizeWith(common.REST_API_KEY).
+		Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Detected == nil {
+		t.Error("the detected language is not reported")
+	}
+	t.Log(tr)
+}